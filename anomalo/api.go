@@ -0,0 +1,358 @@
+package anomalo
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/square/anomalo-go/anomalo"
+)
+
+// The anomalo-go client doesn't cover every public API endpoint the provider needs. The helpers in this file call
+// those endpoints directly with the client's host & credentials. They should be moved into anomalo-go over time.
+
+type warehouse struct {
+	ID            int    `json:"id,omitempty"`
+	Name          string `json:"name,omitempty"`
+	WarehouseType string `json:"warehouse_type,omitempty"`
+}
+
+type listWarehousesResponse struct {
+	Warehouses []warehouse `json:"warehouses,omitempty"`
+}
+
+type tableSummary struct {
+	ID       int    `json:"id,omitempty"`
+	FullName string `json:"full_name,omitempty"`
+}
+
+type listTablesResponse struct {
+	Tables []tableSummary `json:"tables,omitempty"`
+}
+
+func listWarehouses(client *anomalo.Client) ([]warehouse, error) {
+	var data listWarehousesResponse
+	if err := apiGet(client, "list_warehouses", nil, &data); err != nil {
+		return nil, err
+	}
+	return data.Warehouses, nil
+}
+
+// listTables lists the tables Anomalo knows about in a warehouse, whether or not they are configured.
+func listTables(client *anomalo.Client, warehouseID int) ([]tableSummary, error) {
+	var data listTablesResponse
+	params := url.Values{"warehouse_id": []string{strconv.Itoa(warehouseID)}}
+	if err := apiGet(client, "list_tables", params, &data); err != nil {
+		return nil, err
+	}
+	return data.Tables, nil
+}
+
+// apiGet calls a GET endpoint of the public API and decodes the JSON response into out.
+func apiGet(client *anomalo.Client, endpoint string, params url.Values, out interface{}) error {
+	u := fmt.Sprintf("%s/api/public/v1/%s", client.Host, endpoint)
+	if len(params) > 0 {
+		u += "?" + params.Encode()
+	}
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	return doAPIRequest(client, req, out)
+}
+
+// apiPost calls a POST endpoint of the public API with a JSON body and decodes the JSON response into out.
+func apiPost(client *anomalo.Client, endpoint string, body interface{}, out interface{}) error {
+	reqJSON, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	u := fmt.Sprintf("%s/api/public/v1/%s", client.Host, endpoint)
+	req, err := http.NewRequest(http.MethodPost, u, bytes.NewReader(reqJSON))
+	if err != nil {
+		return err
+	}
+	return doAPIRequest(client, req, out)
+}
+
+func doAPIRequest(client *anomalo.Client, req *http.Request, out interface{}) error {
+	req.Header.Set("Authorization", "Bearer "+client.Token)
+	req.Header.Set("Content-Type", "application/json")
+
+	httpClient := &http.Client{}
+	if client.ClientProvider != nil {
+		httpClient = client.ClientProvider()
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return fmt.Errorf("response code %d. unable to read response body. got %w", resp.StatusCode, err)
+		}
+		return &apiError{StatusCode: resp.StatusCode, Body: string(bodyBytes)}
+	}
+
+	// Numbers decode as json.Number, so params keep their exact value.
+	decoder := json.NewDecoder(resp.Body)
+	decoder.UseNumber()
+	return decoder.Decode(out)
+}
+
+// apiError is returned by the helpers in this file when Anomalo responds with a non-200 status code.
+type apiError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *apiError) Error() string {
+	return e.Body
+}
+
+// isNotFoundError reports whether an API error means the requested table or check doesn't exist. Errors from the
+// helpers in this file carry their status code, and only a 404 counts. The anomalo-go client discards status codes,
+// so errors from it are recognized by the messages Anomalo returns for a missing table or check. Other "not found"
+// errors (ex. a missing organization or warehouse connection) must not be mistaken for a deleted object, or Read
+// would drop it from state.
+func isNotFoundError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var apiErr *apiError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusNotFound
+	}
+	return notFoundMessage.MatchString(strings.TrimSpace(errorMessage(err.Error())))
+}
+
+// isRejectedError reports whether Anomalo rejected a request outright (ex. invalid params), so it had no effect.
+// Timeouts & rate limits are 4xx responses too, but the request may still have been applied.
+func isRejectedError(err error) bool {
+	var apiErr *apiError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.StatusCode >= 400 && apiErr.StatusCode < 500 &&
+		apiErr.StatusCode != http.StatusRequestTimeout && apiErr.StatusCode != http.StatusTooManyRequests
+}
+
+// notFoundMessage matches the whole message of an error about a missing table or check, ex. "Table 12 not found" or
+// "Check with static ID 34 does not exist".
+var notFoundMessage = regexp.MustCompile(`(?i)^(the )?(table|check)( with)?( (static )?id)?[\s#:=]*\d*\s+` +
+	`(was )?(not found|does not exist|doesn't exist)\.?$`)
+
+// errorMessage returns the message of an error response body. Anomalo returns either plain text, or a JSON object
+// with the message in one of a few fields.
+func errorMessage(body string) string {
+	var data map[string]interface{}
+	if err := json.Unmarshal([]byte(body), &data); err != nil {
+		return body
+	}
+	for _, field := range []string{"detail", "error", "message"} {
+		if msg, ok := data[field].(string); ok {
+			return msg
+		}
+	}
+	return body
+}
+
+// tableInformation is anomalo.GetTableResponse, limited to the fields the provider uses. Unlike anomalo-go, it
+// decodes time columns that Anomalo reports as objects.
+type tableInformation struct {
+	ID        int    `json:"id,omitempty"`
+	FullName  string `json:"full_name,omitempty"`
+	Warehouse struct {
+		ID   int    `json:"id,omitempty"`
+		Name string `json:"name,omitempty"`
+	} `json:"warehouse,omitempty"`
+	Config struct {
+		TableID                   int          `json:"table_id,omitempty"`
+		CheckCadenceType          string       `json:"check_cadence_type,omitempty"`
+		Definition                string       `json:"definition,omitempty"`
+		TimeColumnType            string       `json:"time_column_type,omitempty"`
+		NotifyAfter               string       `json:"notify_after,omitempty"`
+		NotificationChannelID     int          `json:"notification_channel_id,omitempty"`
+		TimeColumns               []timeColumn `json:"time_columns,omitempty"`
+		FreshAfter                string       `json:"fresh_after,omitempty"`
+		CheckCadenceRunAtDuration string       `json:"check_cadence_run_at_duration,omitempty"`
+		IntervalSkipExpr          string       `json:"interval_skip_expr,omitempty"`
+		AlwaysAlertOnErrors       bool         `json:"always_alert_on_errors,omitempty"`
+	} `json:"config,omitempty"`
+}
+
+// timeColumn is one of a table's time columns. Anomalo accepts & reports time columns either as plain column names,
+// or as objects that also describe the column's format, timezone, and type.
+type timeColumn struct {
+	Name     string `json:"name"`
+	Format   string `json:"format,omitempty"`
+	Timezone string `json:"timezone,omitempty"`
+	Type     string `json:"type,omitempty"`
+}
+
+// timeColumnFields has the same fields as timeColumn, without its JSON methods.
+type timeColumnFields timeColumn
+
+func (c *timeColumn) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*c = timeColumn{Name: name}
+		return nil
+	}
+	return json.Unmarshal(data, (*timeColumnFields)(c))
+}
+
+// MarshalJSON encodes time columns with only a name as plain column names, which every Anomalo version accepts.
+func (c timeColumn) MarshalJSON() ([]byte, error) {
+	if c.Format == "" && c.Timezone == "" && c.Type == "" {
+		return json.Marshal(c.Name)
+	}
+	return json.Marshal(timeColumnFields(c))
+}
+
+// getTableInformation looks up a table by its fully qualified name.
+func getTableInformation(client *anomalo.Client, tableName string) (*tableInformation, error) {
+	var data tableInformation
+	params := url.Values{"table_name": []string{tableName}}
+	if err := apiGet(client, "get_table_information", params, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// getTableInformationByID is getTableInformation, looked up by table ID rather than name. IDs are stable across
+// warehouse & table renames.
+func getTableInformationByID(client *anomalo.Client, tableID int) (*tableInformation, error) {
+	var data tableInformation
+	params := url.Values{"table_id": []string{strconv.Itoa(tableID)}}
+	if err := apiGet(client, "get_table_information", params, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// getTableInformationInWarehouse is getTableInformation for a `schema.table` name within the given warehouse.
+func getTableInformationInWarehouse(client *anomalo.Client, warehouseID int, tableName string) (*tableInformation, error) {
+	var data tableInformation
+	params := url.Values{
+		"warehouse_id": []string{strconv.Itoa(warehouseID)},
+		"table_name":   []string{tableName},
+	}
+	if err := apiGet(client, "get_table_information", params, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// configureTableRequest is anomalo.ConfigureTableRequest, except that it distinguishes unset values from empty ones.
+// Unset (nil) values are left out of the request, so Anomalo applies its own defaults. Empty values are sent as-is.
+type configureTableRequest struct {
+	TableID                   int           `json:"table_id"`
+	CheckCadenceType          *string       `json:"check_cadence_type"`
+	CheckCadenceRunAtDuration *string       `json:"check_cadence_run_at_duration,omitempty"`
+	NotificationChannelID     int           `json:"notification_channel_id,omitempty"`
+	Definition                *string       `json:"definition,omitempty"`
+	TimeColumnType            *string       `json:"time_column_type,omitempty"`
+	NotifyAfter               *string       `json:"notify_after,omitempty"`
+	FreshAfter                *string       `json:"fresh_after,omitempty"`
+	IntervalSkipExpr          *string       `json:"interval_skip_expr,omitempty"`
+	AlwaysAlertOnErrors       *bool         `json:"always_alert_on_errors,omitempty"`
+	TimeColumns               *[]timeColumn `json:"time_columns,omitempty"`
+}
+
+func configureTable(client *anomalo.Client, req configureTableRequest) (*anomalo.ConfigureTableResponse, error) {
+	var data anomalo.ConfigureTableResponse
+	if err := apiPost(client, "configure_table", req, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+type tableColumn struct {
+	Name     string `json:"name,omitempty"`
+	DataType string `json:"data_type,omitempty"`
+}
+
+type getTableColumnsResponse struct {
+	Columns []tableColumn `json:"columns,omitempty"`
+}
+
+// getTableColumns lists the columns of a table, as last profiled by Anomalo.
+func getTableColumns(client *anomalo.Client, tableID int) ([]tableColumn, error) {
+	var data getTableColumnsResponse
+	params := url.Values{"table_id": []string{strconv.Itoa(tableID)}}
+	if err := apiGet(client, "get_table_columns", params, &data); err != nil {
+		return nil, err
+	}
+	return data.Columns, nil
+}
+
+// getChecks is GetChecks, with params decoded exactly. Numbers in params are json.Number rather than float64.
+func getChecks(client *anomalo.Client, tableID int) (*anomalo.GetChecksResponse, error) {
+	var data anomalo.GetChecksResponse
+	params := url.Values{"table_id": []string{strconv.Itoa(tableID)}}
+	if err := apiGet(client, "get_checks_for_table", params, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// getCheckByStaticID is GetCheckByStaticID, with params decoded exactly. Returns nil if no check matches.
+func getCheckByStaticID(client *anomalo.Client, tableID int, staticID int) (*anomalo.Check, error) {
+	return findCheck(client, tableID, fmt.Sprintf("static ID %d", staticID), func(check *anomalo.Check) bool {
+		return check.CheckStaticID == staticID
+	})
+}
+
+// getCheckByRef is GetCheckByRef, with params decoded exactly. Returns nil if no check matches.
+func getCheckByRef(client *anomalo.Client, tableID int, ref string) (*anomalo.Check, error) {
+	return findCheck(client, tableID, fmt.Sprintf("ref %s", ref), func(check *anomalo.Check) bool {
+		return check.Ref == ref
+	})
+}
+
+// findCheck returns the table's only check that matches, or nil if none do.
+func findCheck(client *anomalo.Client, tableID int, description string, matches func(*anomalo.Check) bool) (*anomalo.Check, error) {
+	data, err := getChecks(client, tableID)
+	if err != nil {
+		return nil, err
+	}
+
+	var relevantCheck *anomalo.Check
+	for i := range data.Checks {
+		if !matches(&data.Checks[i]) {
+			continue
+		}
+		if relevantCheck != nil {
+			return nil, fmt.Errorf("saw more than one check with the same %s for table %d. check IDs %d & %d",
+				description, tableID, relevantCheck.CheckID, data.Checks[i].CheckID)
+		}
+		relevantCheck = &data.Checks[i]
+	}
+	return relevantCheck, nil
+}
+
+// createCheckRequest is anomalo.CreateCheckRequest, with params of any JSON type rather than only strings.
+type createCheckRequest struct {
+	CheckType string                 `json:"check_type,omitempty"`
+	Params    map[string]interface{} `json:"params,omitempty"`
+	TableID   int                    `json:"table_id,omitempty"`
+}
+
+func createCheck(client *anomalo.Client, req createCheckRequest) (*anomalo.CreateCheckResponse, error) {
+	var data anomalo.CreateCheckResponse
+	if err := apiPost(client, "create_check", req, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
@@ -0,0 +1,221 @@
+package anomalo
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// checkCatalogJSON lists the check types known to this version of the provider, and the params each one accepts.
+// Bump its version when changing it.
+//
+//go:embed check_catalog.json
+var checkCatalogJSON []byte
+
+// checkCatalog describes the params of each check type, for validating `anomalo_check` params during plan.
+type checkCatalog struct {
+	Version int `json:"version"`
+	// CommonParams are accepted by every check type.
+	CommonParams map[string]checkParamSpec `json:"common_params"`
+	CheckTypes   map[string]checkTypeSpec  `json:"check_types"`
+}
+
+type checkTypeSpec struct {
+	Params map[string]checkParamSpec `json:"params"`
+}
+
+// checkParamSpec describes a param. Type is one of string, integer, number, boolean, or list (of strings). Since
+// `params` only holds strings, numbers, booleans, and lists may also be strings that parse as one.
+type checkParamSpec struct {
+	Type     string   `json:"type"`
+	Required bool     `json:"required"`
+	Enum     []string `json:"enum"`
+	// Default is the value Anomalo applies when the param is unset, if it's documented. Imports leave params with
+	// their default value out of configuration.
+	Default interface{} `json:"default"`
+}
+
+// loadCheckCatalog parses the embedded catalog once. It panics if the catalog is invalid, which is a bug in the
+// provider.
+var loadCheckCatalog = sync.OnceValue(func() checkCatalog {
+	var catalog checkCatalog
+	if err := json.Unmarshal(checkCatalogJSON, &catalog); err != nil {
+		panic(fmt.Sprintf("invalid check catalog: %s", err.Error()))
+	}
+	return catalog
+})
+
+// unknownCheckConfig is how validateParams reports check types & params that aren't in the catalog.
+type unknownCheckConfig int
+
+const (
+	unknownCheckConfigError unknownCheckConfig = iota
+	// unknownCheckConfigWarning allows check types & params Anomalo added after this version of the provider.
+	unknownCheckConfigWarning
+	// unknownCheckConfigIgnored is used before the provider is configured, when its preference isn't known yet.
+	unknownCheckConfigIgnored
+)
+
+// validateParams validates check params against the catalog. Nil values (ex. unknown until apply) are only checked
+// for presence. Diagnostics are reported at paramsPath, or at the key of the invalid param within it when keyed is
+// true.
+func (c checkCatalog) validateParams(checkType string, params map[string]interface{}, paramsPath path.Path, keyed bool,
+	unknown unknownCheckConfig, diags *diag.Diagnostics) {
+	paramPath := func(key string) path.Path {
+		if keyed {
+			return paramsPath.AtMapKey(key)
+		}
+		return paramsPath
+	}
+
+	checkTypeSpec, ok := c.CheckTypes[checkType]
+	if !ok {
+		detail := fmt.Sprintf("%q isn't a check type known to this version of the provider (check type catalog "+
+			"version %d).", checkType, c.Version)
+		if suggestions := closestNames(checkType, c.checkTypeNames()); len(suggestions) > 0 {
+			detail += fmt.Sprintf(" Did you mean %s?", strings.Join(quoteAll(suggestions), " or "))
+		}
+		detail += " If Anomalo added it recently, set `lenient_check_validation = true` in the provider configuration."
+		addCatalogDiagnostic(diags, path.Root("check_type"), "Unknown Check Type", detail, unknown)
+		return
+	}
+
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		spec, ok := checkTypeSpec.Params[key]
+		if !ok {
+			spec, ok = c.CommonParams[key]
+		}
+		if !ok {
+			detail := fmt.Sprintf("%s checks don't have a %q param.", checkType, key)
+			if suggestions := closestNames(key, c.paramNames(checkTypeSpec)); len(suggestions) > 0 {
+				detail += fmt.Sprintf(" Did you mean %s?", strings.Join(quoteAll(suggestions), " or "))
+			}
+			detail += " If Anomalo added it recently, set `lenient_check_validation = true` in the provider " +
+				"configuration."
+			addCatalogDiagnostic(diags, paramPath(key), "Unknown Check Param", detail, unknown)
+			continue
+		}
+		if params[key] == nil {
+			continue
+		}
+		if err := spec.validate(params[key]); err != nil {
+			diags.AddAttributeError(paramPath(key), "Invalid Check Param",
+				fmt.Sprintf("The %q param of %s checks %s.", key, checkType, err.Error()))
+		}
+	}
+
+	for _, key := range c.paramNames(checkTypeSpec) {
+		if _, ok := params[key]; !ok && checkTypeSpec.Params[key].Required {
+			diags.AddAttributeError(paramsPath, "Missing Check Param",
+				fmt.Sprintf("%s checks require the %q param.", checkType, key))
+		}
+	}
+}
+
+// validate returns an error describing why the value doesn't match the spec, or nil if it does.
+func (s checkParamSpec) validate(val interface{}) error {
+	_, isString := val.(string)
+	switch s.Type {
+	case "string":
+		if !isString {
+			return fmt.Errorf("must be a string")
+		}
+	case "integer":
+		if _, err := strconv.ParseInt(paramString(val), 10, 64); err != nil {
+			return fmt.Errorf("must be an integer, got %s", paramString(val))
+		}
+	case "number":
+		if _, err := strconv.ParseFloat(paramString(val), 64); err != nil {
+			return fmt.Errorf("must be a number, got %s", paramString(val))
+		}
+	case "boolean":
+		if _, err := strconv.ParseBool(paramString(val)); err != nil {
+			return fmt.Errorf("must be true or false, got %s", paramString(val))
+		}
+	case "list":
+		if list, ok := val.([]interface{}); ok {
+			for _, element := range list {
+				if _, ok := element.(string); !ok {
+					return fmt.Errorf("must be a list of strings")
+				}
+			}
+		} else if !isString {
+			return fmt.Errorf("must be a list of strings, or a comma-separated string")
+		}
+	}
+
+	if len(s.Enum) > 0 && !slices.Contains(s.Enum, paramString(val)) {
+		return fmt.Errorf("must be one of %s, got %q", strings.Join(quoteAll(s.Enum), ", "), paramString(val))
+	}
+	return nil
+}
+
+// isDefaultParam reports whether val is the catalog's default for the param. Params without a default in the catalog
+// never are.
+func (c checkCatalog) isDefaultParam(checkType, key string, val interface{}) bool {
+	spec, ok := c.CheckTypes[checkType].Params[key]
+	if !ok {
+		spec, ok = c.CommonParams[key]
+	}
+	if !ok || spec.Default == nil || val == nil {
+		return false
+	}
+	switch spec.Type {
+	case "boolean":
+		a, errA := strconv.ParseBool(paramString(val))
+		b, errB := strconv.ParseBool(paramString(spec.Default))
+		return errA == nil && errB == nil && a == b
+	case "integer", "number":
+		a, errA := strconv.ParseFloat(paramString(val), 64)
+		b, errB := strconv.ParseFloat(paramString(spec.Default), 64)
+		return errA == nil && errB == nil && a == b
+	default:
+		return paramString(val) == paramString(spec.Default)
+	}
+}
+
+// addCatalogDiagnostic adds a diagnostic for configuration the catalog doesn't know about.
+func addCatalogDiagnostic(diags *diag.Diagnostics, attributePath path.Path, summary, detail string,
+	unknown unknownCheckConfig) {
+	switch unknown {
+	case unknownCheckConfigError:
+		diags.AddAttributeError(attributePath, summary, detail)
+	case unknownCheckConfigWarning:
+		diags.AddAttributeWarning(attributePath, summary, detail)
+	}
+}
+
+func (c checkCatalog) checkTypeNames() []string {
+	names := make([]string, 0, len(c.CheckTypes))
+	for name := range c.CheckTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// paramNames returns the params of a check type, including common params, sorted.
+func (c checkCatalog) paramNames(checkType checkTypeSpec) []string {
+	names := make([]string, 0, len(checkType.Params)+len(c.CommonParams))
+	for name := range checkType.Params {
+		names = append(names, name)
+	}
+	for name := range c.CommonParams {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,170 @@
+package anomalo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/square/anomalo-go/anomalo"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ list.ListResource              = &checkListResource{}
+	_ list.ListResourceWithConfigure = &checkListResource{}
+)
+
+func newCheckListResource() list.ListResource {
+	return &checkListResource{}
+}
+
+// checkListResource discovers existing checks for `terraform query`, so they can be bulk-imported.
+type checkListResource struct {
+	client *anomalo.Client
+}
+
+// Values expected in the list block configuration
+type checkListResourceModel struct {
+	TableID   types.Int64  `tfsdk:"table_id"`
+	CheckType types.String `tfsdk:"check_type"`
+	RefPrefix types.String `tfsdk:"ref_prefix"`
+}
+
+func (r *checkListResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data := req.ProviderData.(*providerData)
+	r.client = data.client
+}
+
+// Metadata returns the resource type name. It matches the managed resource that results are imported as.
+func (r *checkListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_check"
+}
+
+// ListResourceConfigSchema defines the schema for the list block configuration.
+func (r *checkListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the checks on Anomalo tables, so they can be imported with `terraform query`. System " +
+			"checks are excluded because they can't be managed by `anomalo_check`.",
+		Attributes: map[string]schema.Attribute{
+			"table_id": schema.Int64Attribute{
+				Optional: true,
+				Description: "The ID of the table to list checks for. When unset, checks are listed for every " +
+					"configured table (tables with a `check_cadence_type`), which reads each table Anomalo knows about.",
+			},
+			"check_type": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list checks of this type. Ex `TimeColumnNearNow`.",
+			},
+			"ref_prefix": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list checks whose ref starts with this prefix.",
+			},
+		},
+	}
+}
+
+// List streams every check matching the configured filters, on the configured table or else on every configured
+// table.
+func (r *checkListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config checkListResourceModel
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		var count int64
+		// pushChecks pushes the table's checks that match the filters. Returns false once listing should stop.
+		pushChecks := func(tableID int) bool {
+			checks, err := getChecks(r.client, tableID)
+			if err != nil {
+				push(errorListResult("Error Listing Checks",
+					fmt.Sprintf("Could not list checks for table ID %d, unexpected error: %s", tableID, err.Error())))
+				return false
+			}
+			for i := range checks.Checks {
+				check := &checks.Checks[i]
+				if check.CheckID <= 0 {
+					// System check
+					continue
+				}
+				if !config.CheckType.IsNull() && check.Config.Check != config.CheckType.ValueString() {
+					continue
+				}
+				if !config.RefPrefix.IsNull() && !strings.HasPrefix(check.Ref, config.RefPrefix.ValueString()) {
+					continue
+				}
+
+				if !push(r.listResult(ctx, req, tableID, check)) {
+					return false
+				}
+				count++
+				if req.Limit > 0 && count >= req.Limit {
+					return false
+				}
+			}
+			return true
+		}
+
+		if !config.TableID.IsNull() {
+			pushChecks(int(config.TableID.ValueInt64()))
+			return
+		}
+
+		// Only configured tables have checks that run, so unconfigured ones are skipped.
+		warehouses, err := listWarehouses(r.client)
+		if err != nil {
+			push(errorListResult("Error Listing Checks",
+				fmt.Sprintf("Could not list warehouses, unexpected error: %s", err.Error())))
+			return
+		}
+		for _, w := range warehouses {
+			tables, err := listTables(r.client, w.ID)
+			if err != nil {
+				push(errorListResult("Error Listing Checks",
+					fmt.Sprintf("Could not list tables for warehouse %s, unexpected error: %s", w.Name, err.Error())))
+				return
+			}
+			for _, summary := range tables {
+				table, err := getTableInformationByID(r.client, summary.ID)
+				if err != nil {
+					push(errorListResult("Error Listing Checks",
+						fmt.Sprintf("Could not read table ID %d, unexpected error: %s", summary.ID, err.Error())))
+					return
+				}
+				if table.Config.CheckCadenceType == "" {
+					continue
+				}
+				if !pushChecks(summary.ID) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (r *checkListResource) listResult(ctx context.Context, req list.ListRequest, tableID int, check *anomalo.Check) list.ListResult {
+	result := req.NewListResult(ctx)
+	result.DisplayName = fmt.Sprintf("%s %s (static ID %d)", check.Config.Check, check.Ref, check.CheckStaticID)
+
+	var model checkResourceModel
+	model.TableID = types.Int64Value(int64(tableID))
+	model.IgnoreParams = types.SetNull(types.StringType)
+	result.Diagnostics.Append(model.setFromCheck(check)...)
+	result.Diagnostics.Append(result.Identity.Set(ctx, model.identity())...)
+
+	if req.IncludeResource {
+		result.Diagnostics.Append(result.Resource.Set(ctx, model)...)
+	}
+	return result
+}
@@ -3,25 +3,37 @@ package anomalo
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"sort"
 	"strconv"
-	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/square/anomalo-go/anomalo"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &checkResource{}
-	_ resource.ResourceWithConfigure   = &checkResource{}
-	_ resource.ResourceWithImportState = &checkResource{}
+	_ resource.Resource                     = &checkResource{}
+	_ resource.ResourceWithConfigure        = &checkResource{}
+	_ resource.ResourceWithImportState      = &checkResource{}
+	_ resource.ResourceWithModifyPlan       = &checkResource{}
+	_ resource.ResourceWithIdentity         = &checkResource{}
+	_ resource.ResourceWithUpgradeState     = &checkResource{}
+	_ resource.ResourceWithConfigValidators = &checkResource{}
+	_ resource.ResourceWithValidateConfig   = &checkResource{}
 )
 
 func newCheckResource() resource.Resource {
@@ -29,16 +41,50 @@ func newCheckResource() resource.Resource {
 }
 
 type checkResource struct {
-	client *anomalo.Client
+	client                    *anomalo.Client
+	defaultDeletionProtection bool
+	lenientCheckValidation    bool
+	protectCheckHistory       bool
+	columns                   *tableColumnCache
+	tableProtections          *tableProtections
 }
 
 // Values expected in the state & configuration
 type checkResourceModel struct {
-	TableID       types.Int64  `tfsdk:"table_id"`
-	CheckType     types.String `tfsdk:"check_type"`
-	CheckStaticID types.Int64  `tfsdk:"check_static_id"`
-	Ref           types.String `tfsdk:"ref"`
-	Params        types.Map    `tfsdk:"params"`
+	TableID            types.Int64     `tfsdk:"table_id"`
+	CheckType          types.String    `tfsdk:"check_type"`
+	CheckStaticID      types.Int64     `tfsdk:"check_static_id"`
+	Ref                types.String    `tfsdk:"ref"`
+	Params             types.Map       `tfsdk:"params"`
+	ParamsJSON         paramsJSONValue `tfsdk:"params_json"`
+	IgnoreParams       types.Set       `tfsdk:"ignore_params"`
+	EffectiveParams    types.Map       `tfsdk:"effective_params"`
+	DeletionProtection types.Bool      `tfsdk:"deletion_protection"`
+	AdoptExisting      types.Bool      `tfsdk:"adopt_existing"`
+
+	// Attributes shared by every check type. Anomalo stores them as params. See attributeParams.
+	PriorityLevel         types.String `tfsdk:"priority_level"`
+	Description           types.String `tfsdk:"description"`
+	Enabled               types.Bool   `tfsdk:"enabled"`
+	NotificationChannelID types.Int64  `tfsdk:"notification_channel_id"`
+}
+
+// checkAttributeParams are the params that `anomalo_check` manages as top level attributes, with the same names.
+var checkAttributeParams = map[string]bool{
+	"priority_level":          true,
+	"description":             true,
+	"enabled":                 true,
+	"notification_channel_id": true,
+}
+
+// Values in the resource identity. Checks are identified by their table & static ID, which persist through updates.
+type checkResourceIdentityModel struct {
+	TableID       types.Int64 `tfsdk:"table_id"`
+	CheckStaticID types.Int64 `tfsdk:"check_static_id"`
+}
+
+func (m checkResourceModel) identity() checkResourceIdentityModel {
+	return checkResourceIdentityModel{TableID: m.TableID, CheckStaticID: m.CheckStaticID}
 }
 
 func (r *checkResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
@@ -46,7 +92,13 @@ func (r *checkResource) Configure(_ context.Context, req resource.ConfigureReque
 		return
 	}
 
-	r.client = req.ProviderData.(*anomalo.Client)
+	data := req.ProviderData.(*providerData)
+	r.client = data.client
+	r.defaultDeletionProtection = data.defaultDeletionProtection
+	r.lenientCheckValidation = data.lenientCheckValidation
+	r.protectCheckHistory = data.protectCheckHistory
+	r.columns = data.columns
+	r.tableProtections = data.tableProtections
 }
 
 // Metadata returns the resource type name.
@@ -58,6 +110,7 @@ func (r *checkResource) Metadata(_ context.Context, req resource.MetadataRequest
 func (r *checkResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "An Anomalo check. Closely maps to the check object in the Anomalo API. Updating system checks (checks with negative IDs) is not supported by the Anomalo API and thus is not supported by this resource.",
+		Version:     2,
 		Attributes: map[string]schema.Attribute{
 			"check_static_id": schema.Int64Attribute{
 				Computed: true,
@@ -89,7 +142,8 @@ func (r *checkResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 			"check_type": schema.StringAttribute{
 				Required: true,
 				Description: "The type of check. Valid values are available in the Anomalo API documentation for " +
-					"`create_check`.",
+					"`create_check`. The check type and its params are validated during plan against the check types " +
+					"known to this version of the provider. See the provider's `lenient_check_validation`.",
 			},
 			"ref": schema.StringAttribute{
 				Computed: true,
@@ -101,13 +155,410 @@ func (r *checkResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 					"take precedence if both are provided. Params-based refs may be unsupported in the future.",
 			},
 			"params": schema.MapAttribute{
-				Required:    true,
+				Optional:    true,
 				ElementType: types.StringType,
 				Description: "A map of parameters for the provided check type. Valid values are available in the " +
-					"Anomalo API documentation for `create_check`. Acceptable values differ by check type.",
+					"Anomalo API documentation for `create_check`. Acceptable values differ by check type. Params " +
+					"that name columns (ex. `time_column_target`) are validated against the table's columns during " +
+					"plan, when Anomalo can list them. SQL params (ex. `sql`, `custom_sql`, or any param ending in " +
+					"`_sql`) are compared ignoring whitespace, line endings, and trailing semicolons outside of quoted " +
+					"text and comments, so reformatting SQL doesn't recreate the check. Exactly one of `params` or " +
+					"`params_json` is required.",
+			},
+			"params_json": schema.StringAttribute{
+				CustomType: paramsJSONType{},
+				Optional:   true,
+				Description: "The parameters for the provided check type as a JSON object, ex `jsonencode({ ... })`. " +
+					"Unlike `params`, it preserves numbers, booleans, lists, and nested objects exactly. Differences " +
+					"in key order, whitespace, number formatting, or SQL formatting (see `params`) are ignored. Exactly " +
+					"one of `params` or `params_json` is required.",
 			},
+			"ignore_params": schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Configured params whose value in Anomalo is ignored when detecting drift. Use it for " +
+					"params that Anomalo rewrites after the check is created.",
+			},
+			"effective_params": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "Every param of the check in Anomalo, including defaults Anomalo fills in for params " +
+					"that aren't configured. Only configured params (in `params` or `params_json`) are compared " +
+					"with Anomalo when detecting drift.",
+			},
+			"deletion_protection": deletionProtectionAttribute(),
+			"adopt_existing":      adoptExistingCheckAttribute(),
+		},
+	}
+	for name, attribute := range checkAttributeSchemas() {
+		resp.Schema.Attributes[name] = attribute
+	}
+}
+
+// checkAttributeSchemas returns the schema attributes of checkAttributeParams, shared by all check resources.
+func checkAttributeSchemas() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"priority_level": schema.StringAttribute{
+			Optional:   true,
+			Validators: []validator.String{stringvalidator.OneOf(loadCheckCatalog().CommonParams["priority_level"].Enum...)},
+			Description: "The priority of the check's alerts. One of `low`, `normal`, or `high`. Anomalo's default " +
+				"applies when unset.",
+		},
+		"description": schema.StringAttribute{
+			Optional:    true,
+			Description: "A human-readable description of the check, shown in Anomalo alongside its results.",
+		},
+		"enabled": schema.BoolAttribute{
+			Optional: true,
+			Description: "Whether Anomalo runs the check. Set to false to pause the check without deleting it. " +
+				"Anomalo's default applies when unset.",
 		},
+		"notification_channel_id": schema.Int64Attribute{
+			Optional: true,
+			Description: "The ID of the notification channel for this check's alerts, overriding the table's " +
+				"`notification_channel_id`. Ex `data.anomalo_notification_channel.<name>.id`.",
+		},
+	}
+}
+
+// adoptExistingCheckAttribute is the `adopt_existing` schema attribute shared by all check resources.
+func adoptExistingCheckAttribute() schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Optional: true,
+		Description: "Only used when the check is created. If the table already has a check with the configured " +
+			"`ref` (ex. created by an apply that timed out before saving state), this resource takes it over and " +
+			"updates it to match the configuration. By default, creation fails instead, with the command to import " +
+			"the existing check.",
+	}
+}
+
+// IdentitySchema defines the identity schema for the resource.
+func (r *checkResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"table_id": identityschema.Int64Attribute{
+				RequiredForImport: true,
+				Description:       "The Anomalo ID of the table that the check belongs to.",
+			},
+			"check_static_id": identityschema.Int64Attribute{
+				RequiredForImport: true,
+				Description:       "The static ID of the check. Unlike the check ID, it persists through updates.",
+			},
+		},
+	}
+}
+
+// ConfigValidators returns validators that apply to the resource as a whole.
+func (r *checkResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(path.MatchRoot("params"), path.MatchRoot("params_json")),
+	}
+}
+
+// ValidateConfig validates params against the catalog of check types (see check_catalog.json), so misspelled or
+// badly typed params fail during plan rather than when Anomalo runs the check.
+func (r *checkResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config checkResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || config.CheckType.IsNull() || config.CheckType.IsUnknown() ||
+		config.Params.IsUnknown() || config.ParamsJSON.IsUnknown() || (config.Params.IsNull() && config.ParamsJSON.IsNull()) {
+		return
+	}
+
+	params := map[string]interface{}{}
+	paramsPath, keyed := path.Root("params"), true
+	if !config.ParamsJSON.IsNull() {
+		decoded, err := config.ParamsJSON.params()
+		if err != nil {
+			// Reported by the params_json type.
+			return
+		}
+		params = decoded
+		paramsPath, keyed = path.Root("params_json"), false
+	} else {
+		for key, val := range config.Params.Elements() {
+			params[key] = nil
+			if val, ok := val.(types.String); ok && !val.IsNull() && !val.IsUnknown() {
+				params[key] = val.ValueString()
+			}
+		}
+	}
+
+	for key := range checkAttributeParams {
+		if _, ok := params[key]; ok && !config.attributeIsNull(key) {
+			resp.Diagnostics.AddAttributeError(path.Root(key), "Conflicting Check Attribute",
+				fmt.Sprintf("`%s` is set both as an attribute and in `%s`. Remove it from `%s`.", key,
+					paramsPath.String(), paramsPath.String()))
+		}
+	}
+
+	unknown := unknownCheckConfigError
+	switch {
+	case r.client == nil:
+		// Terraform validates configuration before configuring the provider, and again during plan.
+		unknown = unknownCheckConfigIgnored
+	case r.lenientCheckValidation:
+		unknown = unknownCheckConfigWarning
+	}
+	loadCheckCatalog().validateParams(config.CheckType.ValueString(), params, paramsPath, keyed, unknown,
+		&resp.Diagnostics)
+}
+
+// ModifyPlan applies resource-level plan logic that can't be expressed with attribute plan modifiers.
+func (r *checkResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var state checkResourceModel
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+	planDeletionProtection(ctx, req, resp, r.defaultDeletionProtection, state.description())
+	if resp.Diagnostics.HasError() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan checkResourceModel
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.validateColumnParams(ctx, plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() || req.State.Raw.IsNull() {
+		return
+	}
+
+	// Update only recreates the check if its configuration in Anomalo changes. Otherwise Anomalo's params don't change.
+	if plan.EffectiveParams.IsUnknown() && plan.sameCheck(ctx, state) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("effective_params"), state.EffectiveParams)...)
+	}
+	if configuredChangeRequiresReplace(ctx, req, &resp.Diagnostics, path.Root("table_id"), path.Root("check_static_id")) {
+		warnIfProtectedReplacement(ctx, req.State, state.description(), &resp.Diagnostics)
+		return
+	}
+	r.planRecreation(ctx, plan, state, &resp.Diagnostics)
+}
+
+// planRecreation warns when an update recreates the check in Anomalo. Update keeps the static ID & ref, but the new
+// check has a new check ID, and run history & issue links for the old one don't carry over. With the provider's
+// `protect_check_history`, it's an error for checks with deletion protection, or on tables with deletion protection.
+// Must be called from ModifyPlan.
+func (r *checkResource) planRecreation(ctx context.Context, plan, state checkResourceModel, diags *diag.Diagnostics) {
+	if plan.sameCheck(ctx, state) {
+		return
+	}
+
+	checkID := "unknown"
+	if r.client != nil {
+		check, err := getCheckByStaticID(r.client, int(state.TableID.ValueInt64()), int(state.CheckStaticID.ValueInt64()))
+		if err != nil {
+			tflog.Warn(ctx, "Unable to fetch the check ID of an updated check.", map[string]interface{}{
+				"error": err.Error(),
+			})
+		} else if check != nil {
+			checkID = strconv.Itoa(check.CheckID)
+		}
+	}
+	name := state.CheckType.ValueString()
+	if !state.Description.IsNull() {
+		name = state.Description.ValueString()
+	}
+	detail := fmt.Sprintf("This plan changes check %q (ref %q, check ID %s, static ID %d) on table ID %d. Anomalo "+
+		"applies the change by replacing the check with a new check ID. The ref & static ID are kept, but run "+
+		"history and issue links for check ID %s won't carry over to the new check.", name, state.Ref.ValueString(),
+		checkID, state.CheckStaticID.ValueInt64(), state.TableID.ValueInt64(), checkID)
+
+	if !r.protectCheckHistory {
+		diags.AddWarning("Check Update Replaces the Check ID", detail)
+		return
+	}
+	if state.DeletionProtection.ValueBool() {
+		diags.AddError("Check Update Would Lose Run History", detail+" The check has `deletion_protection` "+
+			"enabled and the provider has `protect_check_history` enabled. To allow the update, set "+
+			"`deletion_protection = false` on the check and apply it first.")
+		return
+	}
+	if r.tableProtections.isProtected(int(state.TableID.ValueInt64())) {
+		diags.AddError("Check Update Would Lose Run History", detail+" The check's table has "+
+			"`deletion_protection` enabled and the provider has `protect_check_history` enabled. To allow the "+
+			"update, set `deletion_protection = false` on the table and apply it first.")
+		return
 	}
+	diags.AddWarning("Check Update Replaces the Check ID", detail)
+}
+
+// sameCheck reports whether the model configures the check in Anomalo the same way as prior, so that an update
+// doesn't need to recreate the check. Params are compared with normalizeParams, so cosmetic SQL changes are ignored.
+func (m checkResourceModel) sameCheck(ctx context.Context, prior checkResourceModel) bool {
+	if !m.CheckType.Equal(prior.CheckType) || !(m.Ref.IsUnknown() || m.Ref.Equal(prior.Ref)) ||
+		m.Params.IsUnknown() || m.ParamsJSON.IsUnknown() || m.attributesUnknown() {
+		return false
+	}
+	m.Params = paramsWithoutLegacyRef(m.Params, prior.Ref)
+	prior.Params = paramsWithoutLegacyRef(prior.Params, prior.Ref)
+
+	params, diags := m.requestParams(ctx)
+	priorParams, priorDiags := prior.requestParams(ctx)
+	if diags.HasError() || priorDiags.HasError() {
+		return false
+	}
+	return reflect.DeepEqual(normalizeParams(params), normalizeParams(priorParams))
+}
+
+// validateColumnParams checks that params naming columns refer to columns of the check's table, so typos are caught
+// before Anomalo runs the check.
+func (r *checkResource) validateColumnParams(ctx context.Context, plan checkResourceModel, diags *diag.Diagnostics) {
+	if plan.TableID.IsUnknown() || plan.Params.IsUnknown() || plan.ParamsJSON.IsUnknown() {
+		return
+	}
+	params, paramDiags := plan.requestParams(ctx)
+	if paramDiags.HasError() {
+		// Reported by Create & Update.
+		return
+	}
+	paramsPath := path.Root("params")
+	if !plan.ParamsJSON.IsNull() {
+		paramsPath = path.Root("params_json")
+	}
+
+	tableID := int(plan.TableID.ValueInt64())
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if !columnParams[key] {
+			continue
+		}
+		var names []string
+		switch value := params[key].(type) {
+		case string:
+			names = parseColumnParam(value)
+		case []interface{}:
+			for _, name := range value {
+				if name, ok := name.(string); ok {
+					names = append(names, name)
+				}
+			}
+		}
+		attributePath := paramsPath
+		if plan.ParamsJSON.IsNull() {
+			attributePath = paramsPath.AtMapKey(key)
+		}
+		r.columns.validateColumns(ctx, tableID, fmt.Sprintf("table ID %d", tableID), attributePath, names, diags)
+	}
+}
+
+// requestParams returns the configured params, from either `params` or `params_json`, along with the top level
+// attributes Anomalo stores as params, for a create_check request. Unknown params are left out.
+func (m checkResourceModel) requestParams(ctx context.Context) (map[string]interface{}, diag.Diagnostics) {
+	var params map[string]interface{}
+	var diags diag.Diagnostics
+	if !m.ParamsJSON.IsNull() {
+		var err error
+		params, err = m.ParamsJSON.params()
+		if err != nil {
+			return nil, diag.Diagnostics{diag.NewAttributeErrorDiagnostic(path.Root("params_json"),
+				"Invalid Params JSON", err.Error())}
+		}
+	} else {
+		var target map[string]string
+		diags = m.Params.ElementsAs(ctx, &target, true)
+		params = make(map[string]interface{}, len(target))
+		for key, val := range target {
+			params[key] = val
+		}
+	}
+
+	for key, val := range m.attributeParams() {
+		params[key] = val
+	}
+	return params, diags
+}
+
+// attributeParams returns the values of the top level attributes that Anomalo stores as params. Null & unknown
+// attributes are left out.
+func (m checkResourceModel) attributeParams() map[string]interface{} {
+	params := map[string]interface{}{}
+	if val := stringPtr(m.PriorityLevel); val != nil {
+		params["priority_level"] = *val
+	}
+	if val := stringPtr(m.Description); val != nil {
+		params["description"] = *val
+	}
+	if val := boolPtr(m.Enabled); val != nil {
+		params["enabled"] = *val
+	}
+	if !m.NotificationChannelID.IsNull() && !m.NotificationChannelID.IsUnknown() {
+		params["notification_channel_id"] = m.NotificationChannelID.ValueInt64()
+	}
+	return params
+}
+
+// attributesUnknown reports whether any top level attribute that Anomalo stores as a param is unknown.
+func (m checkResourceModel) attributesUnknown() bool {
+	return m.PriorityLevel.IsUnknown() || m.Description.IsUnknown() || m.Enabled.IsUnknown() ||
+		m.NotificationChannelID.IsUnknown()
+}
+
+// attributeIsNull reports whether the top level attribute for a param in checkAttributeParams is null.
+func (m checkResourceModel) attributeIsNull(key string) bool {
+	switch key {
+	case "priority_level":
+		return m.PriorityLevel.IsNull()
+	case "description":
+		return m.Description.IsNull()
+	case "enabled":
+		return m.Enabled.IsNull()
+	case "notification_channel_id":
+		return m.NotificationChannelID.IsNull()
+	default:
+		return true
+	}
+}
+
+// setAttributesFromParams sets the top level attributes that Anomalo stores as params. Like table attributes (see
+// stringFromAPI), attributes that are null stay null unless adopt is true.
+func (m *checkResourceModel) setAttributesFromParams(checkType string, params map[string]interface{}, adopt bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+	// Values that are Anomalo's default aren't adopted, like empty ones.
+	adoptParam := func(key string) bool {
+		return adopt && !loadCheckCatalog().isDefaultParam(checkType, key, params[key])
+	}
+	m.PriorityLevel = stringFromAPI(m.PriorityLevel, paramString(params["priority_level"]), adoptParam("priority_level"))
+	m.Description = stringFromAPI(m.Description, paramString(params["description"]), adoptParam("description"))
+
+	enabled := params["enabled"]
+	switch {
+	case enabled == nil || (m.Enabled.IsNull() && !adoptParam("enabled")):
+		m.Enabled = types.BoolNull()
+	default:
+		b, err := strconv.ParseBool(paramString(enabled))
+		if err != nil {
+			diags.AddAttributeError(path.Root("enabled"), "Unexpected Check Param",
+				fmt.Sprintf("Anomalo returned %q for `enabled`, which isn't a boolean.", paramString(enabled)))
+		}
+		m.Enabled = types.BoolValue(b)
+	}
+
+	channelID := params["notification_channel_id"]
+	switch {
+	case isEmptyParam(channelID) || (m.NotificationChannelID.IsNull() && !adoptParam("notification_channel_id")):
+		m.NotificationChannelID = types.Int64Null()
+	default:
+		id, err := strconv.ParseInt(paramString(channelID), 10, 64)
+		if err != nil {
+			diags.AddAttributeError(path.Root("notification_channel_id"), "Unexpected Check Param",
+				fmt.Sprintf("Anomalo returned %q for `notification_channel_id`, which isn't an ID.",
+					paramString(channelID)))
+		}
+		m.NotificationChannelID = types.Int64Value(id)
+	}
+	return diags
+}
+
+// description is a human-readable identifier for the check, for use in diagnostics.
+func (m checkResourceModel) description() string {
+	return fmt.Sprintf("check %q (static ID %d) on table ID %d",
+		m.Ref.ValueString(), m.CheckStaticID.ValueInt64(), m.TableID.ValueInt64())
 }
 
 // Create creates the resource and sets the initial Terraform state.
@@ -120,35 +571,46 @@ func (r *checkResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	resp.Diagnostics.Append(r.create(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	setIdentity(ctx, resp.Identity, plan.identity(), &resp.Diagnostics)
+}
+
+// create creates the planned check in Anomalo, and sets the values Anomalo assigns in the plan. It's shared by every
+// check resource.
+func (r *checkResource) create(ctx context.Context, plan *checkResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
 	if !plan.CheckStaticID.IsNull() && !plan.CheckStaticID.IsUnknown() {
-		resp.Diagnostics.AddError(
+		diags.AddError(
 			"Error Creating Check",
 			fmt.Sprintf(
 				"Specifying a `check_static_id` during creation is not supported. If you are "+
 					"creating a new check, set check_static_id to null. If you are trying to update an existing "+
 					"check, first import it with `terraform import <terraform-resource-identifier> %d,%d`",
-				plan.TableID,
-				plan.CheckStaticID,
+				plan.TableID.ValueInt64(),
+				plan.CheckStaticID.ValueInt64(),
 			),
 		)
-		return
+		return diags
 	}
 
 	// Create the API request based on the plan
-	var target map[string]string
-	diags = plan.Params.ElementsAs(ctx, &target, true)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
+	target, paramDiags := plan.requestParams(ctx)
+	diags.Append(paramDiags...)
+	if diags.HasError() {
+		return diags
 	}
 
 	if _, ok := target["ref"]; ok {
-		resp.Diagnostics.AddWarning("Ref defined in `params` for check",
-			"Check with static ID %d for table ID %d had a `ref` defined in params. Ref is now a top level field"+
-				"in the API, but may be present in `params` if you made a mistake or upgraded from a previous version of "+
-				"this provider. The top level check ref, if defined, will take precedence. Param-based `ref`s may be "+
-				"unsupported in future versions of the plugin. Removal of top level Ref will be ignored if one is "+
-				"defined in the params.")
+		diags.Append(legacyRefWarning(plan.description()))
 	}
 
 	// Overwrite params-based ref if a top-level ref is provided.
@@ -156,21 +618,37 @@ func (r *checkResource) Create(ctx context.Context, req resource.CreateRequest,
 		target["ref"] = plan.Ref.ValueString()
 	}
 
-	createCheckReq := anomalo.CreateCheckRequest{
+	createCheckReq := createCheckRequest{
 		TableID:   int(plan.TableID.ValueInt64()),
 		CheckType: plan.CheckType.ValueString(),
 		Params:    target,
 	}
 
+	// Creating a check with a ref that's already used would duplicate it, ex. when a prior apply created the check
+	// but timed out before saving state.
+	if ref, _ := target["ref"].(string); ref != "" {
+		existing, err := getCheckByRef(r.client, createCheckReq.TableID, ref)
+		if err != nil {
+			diags.AddError(
+				"Error Creating Check",
+				fmt.Sprintf("Could not look for an existing check with ref %q on table ID %d, unexpected error: %s",
+					ref, createCheckReq.TableID, err.Error()),
+			)
+			return diags
+		}
+		if existing != nil {
+			return r.adoptCheck(ctx, plan, existing)
+		}
+	}
+
 	// Create new check
-	var createCheckResponse *anomalo.CreateCheckResponse
-	createCheckResponse, err := r.client.CreateCheck(createCheckReq)
+	createCheckResponse, err := createCheck(r.client, createCheckReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		diags.AddError(
 			"Error Creating Check",
 			fmt.Sprintf("Could not create check, unexpected error: %s", err.Error()),
 		)
-		return
+		return diags
 	}
 
 	// Map response body back into the plan, and set the state to plan values. Some values in the plan do not
@@ -178,11 +656,179 @@ func (r *checkResource) Create(ctx context.Context, req resource.CreateRequest,
 	plan.TableID = types.Int64Value(int64(createCheckReq.TableID))
 	plan.CheckStaticID = types.Int64Value(int64(createCheckResponse.CheckStaticId))
 	plan.Ref = types.StringValue(createCheckResponse.CheckRef)
+	diags.Append(r.setEffectiveParams(plan, createCheckReq.Params)...)
+	return diags
+}
 
-	diags = resp.State.Set(ctx, plan)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
+// setFromCheck maps an Anomalo API check into the model. Params are set in whichever of `params` or `params_json` is
+// in use. Only params that are already in the model are tracked, so defaults Anomalo fills in don't show up as drift.
+// They're visible in `effective_params` instead. When neither `params` nor `params_json` is in use (ex. imports),
+// every param Anomalo has is adopted, in `params` unless some values aren't strings.
+func (m *checkResourceModel) setFromCheck(check *anomalo.Check) diag.Diagnostics {
+	var diags diag.Diagnostics
+	prior := m.priorParams()
+	ignored := map[string]bool{}
+	for _, key := range m.IgnoreParams.Elements() {
+		if key, ok := key.(types.String); ok {
+			ignored[key.ValueString()] = true
+		}
+	}
+	adopt := m.Params.IsNull() && m.ParamsJSON.IsNull()
+
+	params := map[string]interface{}{}
+	for key, val := range check.Config.Params {
+		priorVal, tracked := prior[key]
+		switch {
+		case tracked && ignored[key]:
+			params[key] = priorVal
+		case tracked && isSQLParam(key) && isSQLEqual(priorVal, val):
+			// Anomalo reformats SQL. Keep the configured formatting.
+			params[key] = priorVal
+		case tracked && reflect.DeepEqual(normalizeJSON(priorVal), normalizeJSON(val)):
+			// Anomalo may return numbers as strings, or reformat them. Keep the configured value.
+			params[key] = priorVal
+		case tracked:
+			if val != nil {
+				params[key] = val
+			}
+		case adopt && !isEmptyParam(val) && key != "ref" && key != "check_static_id" && !checkAttributeParams[key] &&
+			!loadCheckCatalog().isDefaultParam(check.Config.Check, key, val):
+			// `ref`, `check_static_id`, and checkAttributeParams are top level attributes. Empty values are unset in
+			// Anomalo, and values the catalog lists as Anomalo's default are equivalent to unset. Both are left out so
+			// that imported configuration only contains meaningful params.
+			params[key] = val
+		}
+	}
+	for key := range ignored {
+		if _, ok := params[key]; !ok && prior[key] != nil {
+			params[key] = prior[key]
+		}
+	}
+
+	m.CheckStaticID = types.Int64Value(int64(check.CheckStaticID))
+	m.CheckType = types.StringValue(check.Config.Check)
+	m.Ref = types.StringValue(check.Ref)
+	m.EffectiveParams, diags = effectiveParamsValue(check.Config.Params)
+	diags.Append(m.setAttributesFromParams(check.Config.Check, check.Config.Params, adopt)...)
+
+	allStrings := true
+	for _, val := range params {
+		if _, ok := val.(string); !ok {
+			allStrings = false
+		}
+	}
+	if !m.ParamsJSON.IsNull() || (adopt && !allStrings) {
+		paramsJSON, err := newParamsJSONValue(params)
+		if err != nil {
+			diags.AddError("Error Reading Check Params", err.Error())
+			return diags
+		}
+		m.ParamsJSON = paramsJSON
+		m.Params = types.MapNull(types.StringType)
+		return diags
+	}
+
+	mapVal := map[string]attr.Value{}
+	for key, val := range params {
+		mapVal[key] = types.StringValue(paramString(val))
+	}
+	mapParams, mapDiags := types.MapValue(types.StringType, mapVal)
+	diags.Append(mapDiags...)
+	m.Params = mapParams
+	m.ParamsJSON = newParamsJSONNull()
+	return diags
+}
+
+// isSQLEqual reports whether two param values are SQL strings that differ only cosmetically.
+func isSQLEqual(a, b interface{}) bool {
+	aSQL, aOK := a.(string)
+	bSQL, bOK := b.(string)
+	return aOK && bOK && sqlEqual(aSQL, bSQL)
+}
+
+// priorParams returns the params currently in the model, from either `params` or `params_json`.
+func (m checkResourceModel) priorParams() map[string]interface{} {
+	prior := map[string]interface{}{}
+	for key, val := range m.Params.Elements() {
+		if val, ok := val.(types.String); ok && !val.IsNull() && !val.IsUnknown() {
+			prior[key] = val.ValueString()
+		}
+	}
+	if !m.ParamsJSON.IsNull() && !m.ParamsJSON.IsUnknown() {
+		if params, err := m.ParamsJSON.params(); err == nil {
+			for key, val := range params {
+				prior[key] = val
+			}
+		}
+	}
+	return prior
+}
+
+// setEffectiveParams sets `effective_params` after a check is created, from the check in Anomalo. If the check can't
+// be fetched, the requested params are used.
+func (r *checkResource) setEffectiveParams(plan *checkResourceModel, requested map[string]interface{}) diag.Diagnostics {
+	params := requested
+	check, err := getCheckByStaticID(r.client, int(plan.TableID.ValueInt64()), int(plan.CheckStaticID.ValueInt64()))
+	if err == nil && check != nil {
+		params = check.Config.Params
+	}
+
+	var diags diag.Diagnostics
+	plan.EffectiveParams, diags = effectiveParamsValue(params)
+	return diags
+}
+
+// effectiveParamsValue converts a check's params in Anomalo to the value of `effective_params`.
+func effectiveParamsValue(params map[string]interface{}) (types.Map, diag.Diagnostics) {
+	effectiveParams := map[string]attr.Value{}
+	for key, val := range params {
+		if val != nil {
+			effectiveParams[key] = types.StringValue(paramString(val))
+		}
+	}
+	return types.MapValue(types.StringType, effectiveParams)
+}
+
+// paramsWithoutLegacyRef returns params without a `ref` key that duplicates the top level ref. Configuration written
+// before ref was a top level attribute may still set it in params, while upgraded state has it at the top level.
+func paramsWithoutLegacyRef(params types.Map, ref types.String) types.Map {
+	elements := params.Elements()
+	legacyRef, ok := elements["ref"]
+	if !ok || !legacyRef.Equal(types.StringValue(ref.ValueString())) {
+		return params
+	}
+	trimmed := make(map[string]attr.Value, len(elements))
+	for key, val := range elements {
+		if key != "ref" {
+			trimmed[key] = val
+		}
+	}
+	return types.MapValueMust(types.StringType, trimmed)
+}
+
+// legacyRefWarning is shown when the configuration sets the check's ref in params rather than the top level ref.
+func legacyRefWarning(checkDescription string) diag.Diagnostic {
+	return diag.NewWarningDiagnostic("Ref defined in `params` for check",
+		fmt.Sprintf("The configuration for %s has a `ref` defined in params. Ref is now a top level field in the "+
+			"API, but may be present in `params` if you made a mistake or upgraded from a previous version of this "+
+			"provider. Move it to the top level `ref` attribute. The top level check ref, if defined, will take "+
+			"precedence. Param-based `ref`s may be unsupported in future versions of the plugin. Removal of top "+
+			"level Ref will be ignored if one is defined in the params.", checkDescription))
+}
+
+// isEmptyParam reports whether a param value from the API is unset.
+func isEmptyParam(val interface{}) bool {
+	switch v := val.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	default:
+		return false
 	}
 }
 
@@ -194,93 +840,151 @@ func (r *checkResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
+	found, diags := r.read(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	setIdentity(ctx, resp.Identity, state.identity(), &resp.Diagnostics)
+}
+
+// read refreshes the model from the check in Anomalo. It returns false if the check no longer exists. It's shared by
+// every check resource.
+func (r *checkResource) read(ctx context.Context, state *checkResourceModel) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
 	// Fetch the check from Anomalo
 	var check *anomalo.Check
 	var err error
 	if int(state.CheckStaticID.ValueInt64()) != 0 {
-		check, err = r.client.GetCheckByStaticID(int(state.TableID.ValueInt64()), int(state.CheckStaticID.ValueInt64()))
-		if err != nil {
-			resp.Diagnostics.AddError(
+		check, err = getCheckByStaticID(r.client, int(state.TableID.ValueInt64()), int(state.CheckStaticID.ValueInt64()))
+		if err != nil && !isNotFoundError(err) {
+			diags.AddError(
 				"Error Reading Checks",
 				fmt.Sprintf("Could not read check for table ID %d, static ID %d, unexpected error: %s",
-					state.TableID.ValueInt64(), state.CheckStaticID, err.Error()),
+					state.TableID.ValueInt64(), state.CheckStaticID.ValueInt64(), err.Error()),
 			)
-			return
+			return false, diags
 		}
 	} else if state.Ref.ValueString() != "" {
 		// Ref-only reads are supported only for imports. Unfortunately it's not easy to error when this isn't the case.
-		resp.Diagnostics.AddWarning("Reading Check by Ref",
+		diags.AddWarning("Reading Check by Ref",
 			fmt.Sprintf("The requested check has a static_id of 0. This should only happen when importing by "+
-				"Ref. Table ID: %d, Ref: %s, StaticId: %d", state.TableID, state.Ref, state.CheckStaticID))
-		check, err = r.client.GetCheckByRef(int(state.TableID.ValueInt64()), state.Ref.ValueString())
-		if err != nil {
-			resp.Diagnostics.AddError(
+				"Ref. Table ID: %d, Ref: %s, StaticId: %d", state.TableID.ValueInt64(), state.Ref.ValueString(),
+				state.CheckStaticID.ValueInt64()))
+		check, err = getCheckByRef(r.client, int(state.TableID.ValueInt64()), state.Ref.ValueString())
+		if err != nil && !isNotFoundError(err) {
+			diags.AddError(
 				"Error Reading Checks",
 				fmt.Sprintf("Could not read check for table ID %d, ref %s, unexpected error: %s",
 					state.TableID.ValueInt64(), state.Ref.ValueString(), err.Error()),
 			)
-			return
+			return false, diags
 		}
 	} else {
 		// Not expected but possible if there is a bug elsewhere in the provider.
-		resp.Diagnostics.AddError("Error Reading Check",
+		diags.AddError("Error Reading Check",
 			fmt.Sprintf("Error in terraform state - a check for table ID %d has a static ID of 0 and Ref of %s. "+
 				"Remove it from your state and re-import if necessary. If this issue persists notify the maintainer of "+
-				"the provider.", state.TableID, state.Ref))
-		return
+				"the provider.", state.TableID.ValueInt64(), state.Ref.ValueString()))
+		return false, diags
 	}
 
 	if check == nil {
-		// Check was deleted remotely
-		resp.State.RemoveResource(ctx)
-		return
+		// Check (or its table) was deleted remotely. Plan to recreate it.
+		tflog.Warn(ctx, "Check no longer exists in Anomalo. Removing it from state.", map[string]interface{}{
+			"table_id":        state.TableID.ValueInt64(),
+			"check_static_id": state.CheckStaticID.ValueInt64(),
+			"ref":             state.Ref.ValueString(),
+		})
+		return false, diags
 	}
 
-	// Convert map values into terraform types.
-	mapVal := map[string]attr.Value{}
-	for key, val := range check.Config.Params {
-		if val != nil {
-			// Note: consider using safer string conversion based on runtime type of val
-			// There is no "generic" terraform type analogous to interface{}, so cast to a string
-			mapVal[key] = types.StringValue(fmt.Sprintf("%v", val))
-		}
-	}
-	mapParams, diag := types.MapValue(types.StringType, mapVal)
-	resp.Diagnostics.Append(diag...)
+	// Map response body back into the state.
+	diags.Append(state.setFromCheck(check)...)
+	return true, diags
+}
+
+func (r *checkResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan checkResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Map response body back into the state, and set the response state to the updated state.
-	state.CheckStaticID = types.Int64Value(int64(check.CheckStaticID))
-	state.CheckType = types.StringValue(check.Config.Check)
-	state.Ref = types.StringValue(check.Ref)
-	state.Params = mapParams
-
-	diags = resp.State.Set(ctx, &state)
+	var state checkResourceModel
+	diags = req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-}
 
-func (r *checkResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan checkResourceModel
-	diags := req.Plan.Get(ctx, &plan)
-	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(r.update(ctx, &plan, state)...)
 	if resp.Diagnostics.HasError() {
+		// Only verified updates are saved. The next plan reconciles the prior state with Anomalo.
+		resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
 		return
 	}
 
-	var state checkResourceModel
-	diags = req.State.Get(ctx, &state)
+	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	setIdentity(ctx, resp.Identity, plan.identity(), &resp.Diagnostics)
+}
 
+// adoptCheck takes over an existing check with the planned ref, if `adopt_existing` is set, by updating it to match
+// the plan. Otherwise it returns a conflict error.
+func (r *checkResource) adoptCheck(ctx context.Context, plan *checkResourceModel, existing *anomalo.Check) diag.Diagnostics {
+	var diags diag.Diagnostics
+	tableID := plan.TableID.ValueInt64()
+	if !plan.AdoptExisting.ValueBool() {
+		diags.AddError(
+			"Check Already Exists",
+			fmt.Sprintf("Table ID %d already has a check with ref %q (check ID %d, static ID %d), possibly created by "+
+				"an earlier apply that didn't finish. To manage it with this resource, import it with `terraform "+
+				"import <terraform-resource-identifier> %d,%d`, or set `adopt_existing = true`. Otherwise, use a "+
+				"different ref.", tableID, existing.Ref, existing.CheckID, existing.CheckStaticID, tableID,
+				existing.CheckStaticID),
+		)
+		return diags
+	}
+
+	tflog.Info(ctx, "Adopting an existing check with the planned ref.", map[string]interface{}{
+		"table_id":        tableID,
+		"check_static_id": existing.CheckStaticID,
+		"ref":             existing.Ref,
+	})
+	// The existing check is the prior state of the update. Params the plan configures are tracked, so update only
+	// recreates the check if they differ.
+	prior := *plan
+	prior.CheckStaticID = types.Int64Value(int64(existing.CheckStaticID))
+	diags.Append(prior.setFromCheck(existing)...)
+	if diags.HasError() {
+		return diags
+	}
+	diags.Append(r.update(ctx, plan, prior)...)
+	return diags
+}
+
+// update applies the plan to the check in Anomalo, identified by the static ID in state, and sets the values Anomalo
+// assigns in the plan. It's shared by every check resource.
+func (r *checkResource) update(ctx context.Context, plan *checkResourceModel, state checkResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
 	if !plan.CheckStaticID.IsNull() && !plan.CheckStaticID.IsUnknown() && !plan.CheckStaticID.Equal(state.CheckStaticID) {
-		resp.Diagnostics.AddError(
+		diags.AddError(
 			"Error Updating Check",
 			fmt.Sprintf("Updating the check_static_id to %s for table %s is not supported. If you want to create"+
 				" a new check, delete this resource and create a new one with a null or undefined check_static_id. If "+
@@ -288,41 +992,52 @@ func (r *checkResource) Update(ctx context.Context, req resource.UpdateRequest,
 				"`terraform import <terraform-resource-identifier> %s,%s`",
 				plan.CheckStaticID.String(), plan.TableID.String(), plan.TableID.String(), plan.CheckStaticID.String()),
 		)
-		return
+		return diags
+	}
+
+	// `deletion_protection` & `ignore_params` only exist in terraform. Recreating the check for them would needlessly
+	// churn check IDs.
+	if plan.sameCheck(ctx, state) {
+		plan.CheckStaticID = state.CheckStaticID
+		plan.Ref = state.Ref
+		plan.EffectiveParams = state.EffectiveParams
+		return diags
 	}
 
 	// Make sure the check you're updating exists.
-	existingCheck, err := r.client.GetCheckByStaticID(int(state.TableID.ValueInt64()), int(state.CheckStaticID.ValueInt64()))
+	existingCheck, err := getCheckByStaticID(r.client, int(state.TableID.ValueInt64()), int(state.CheckStaticID.ValueInt64()))
 	if err != nil {
-		resp.Diagnostics.AddError(
+		diags.AddError(
 			"Error Updating Check",
 			fmt.Sprintf("Could not update check with static ID %d for table ID %d. Unable to find check to "+
 				"delete. If you'd like to create this check, give it a new resource name and blank check_static_id. "+
 				"Error: %s",
 				state.CheckStaticID.ValueInt64(), state.TableID.ValueInt64(), err.Error()),
 		)
-		return
+		return diags
+	}
+	if existingCheck == nil {
+		diags.AddError(
+			"Error Updating Check",
+			fmt.Sprintf("Check with static ID %d for table ID %d no longer exists in Anomalo. Run `terraform "+
+				"plan` again to recreate it.", state.CheckStaticID.ValueInt64(), state.TableID.ValueInt64()),
+		)
+		return diags
 	}
 
 	// "Updating" checks is (confusingly) accomplished by setting check_static_id in the Params of the Check we are
 	// creating. Behind the scenes, Anomalo is creating a new check with a new ID and deleting the old check.
 
 	// Build the request to Create a new check
-	var target map[string]string
-	diags = plan.Params.ElementsAs(ctx, &target, true)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
+	target, paramDiags := plan.requestParams(ctx)
+	diags.Append(paramDiags...)
+	if diags.HasError() {
+		return diags
 	}
 	target["check_static_id"] = strconv.Itoa(existingCheck.CheckStaticID)
 
 	if _, ok := target["ref"]; ok {
-		resp.Diagnostics.AddWarning("Ref defined in `params` for check",
-			"Check with static ID %d for table ID %d had a `ref` defined in params. Ref is now a top level field"+
-				"in the API, but may be present in `params` if you made a mistake or upgraded from a previous version of "+
-				"this provider. The top level check ref, if defined, will take precedence. Param-based `ref`s may be "+
-				"unsupported in future versions of the plugin. Removal of top level Ref will be ignored if one is "+
-				"defined in the params.")
+		diags.Append(legacyRefWarning(plan.description()))
 	}
 
 	// Overwrites param-based `Ref` if top level `Ref` exists.
@@ -331,32 +1046,28 @@ func (r *checkResource) Update(ctx context.Context, req resource.UpdateRequest,
 	}
 
 	checkType := plan.CheckType.ValueString()
-	createCheckReq := anomalo.CreateCheckRequest{
+	createCheckReq := createCheckRequest{
 		TableID:   int(plan.TableID.ValueInt64()),
 		CheckType: checkType,
 		Params:    target,
 	}
 
-	// Create new check
-	createResponse, err := r.client.CreateCheck(createCheckReq)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Updating Check",
-			fmt.Sprintf("Could not create check with type %s for table %d, unexpected error: %s",
-				createCheckReq.CheckType, createCheckReq.TableID, err.Error()),
-		)
-		return
+	// Create new check. Anomalo replaces the check in several steps, any of which can fail, so the result is verified
+	// whether or not the request succeeds.
+	_, err = createCheck(r.client, createCheckReq)
+	updated, verifyDiags := r.verifyCheckUpdate(ctx, state.description(), existingCheck, createCheckReq, err)
+	diags.Append(verifyDiags...)
+	if updated == nil {
+		return diags
 	}
 
-	// Most plan/state values should not change based on the response of CreateCheck.
-	plan.CheckStaticID = types.Int64Value(int64(createResponse.CheckStaticId))
-	plan.Ref = types.StringValue(createResponse.CheckRef) // A checkRef might be created if one does not exist.
-
-	diags = resp.State.Set(ctx, plan)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
+	// Most plan/state values should not change based on the updated check.
+	plan.CheckStaticID = types.Int64Value(int64(updated.CheckStaticID))
+	plan.Ref = types.StringValue(updated.Ref) // A checkRef might be created if one does not exist.
+	effectiveParams, paramDiags := effectiveParamsValue(updated.Config.Params)
+	diags.Append(paramDiags...)
+	plan.EffectiveParams = effectiveParams
+	return diags
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -368,15 +1079,34 @@ func (r *checkResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	existingCheck, err := r.client.GetCheckByStaticID(int(plan.TableID.ValueInt64()), int(plan.CheckStaticID.ValueInt64()))
-	if err != nil || existingCheck == nil {
-		resp.Diagnostics.AddError(
+	resp.Diagnostics.Append(r.delete(ctx, plan)...)
+}
+
+// delete deletes the check in Anomalo, unless it's protected from deletion. Checks that no longer exist are ignored.
+// It's shared by every check resource.
+func (r *checkResource) delete(ctx context.Context, plan checkResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if plan.DeletionProtection.ValueBool() {
+		diags.Append(deletionProtectedError(plan.description()))
+		return diags
+	}
+
+	existingCheck, err := getCheckByStaticID(r.client, int(plan.TableID.ValueInt64()), int(plan.CheckStaticID.ValueInt64()))
+	if isNotFoundError(err) || (err == nil && existingCheck == nil) {
+		tflog.Warn(ctx, "Check no longer exists in Anomalo. Nothing to delete.", map[string]interface{}{
+			"table_id":        plan.TableID.ValueInt64(),
+			"check_static_id": plan.CheckStaticID.ValueInt64(),
+		})
+		return diags
+	}
+	if err != nil {
+		diags.AddError(
 			"Error Deleting Check",
 			fmt.Sprintf("Error deleting check with static ID %d on table ID %d. This may be due to a race "+
 				"condition. If the error persists contact the provider maintainer. Unexpected error: %s",
 				plan.CheckStaticID.ValueInt64(), plan.TableID.ValueInt64(), err.Error()),
 		)
-		return
+		return diags
 	}
 
 	deleteRequest := anomalo.DeleteCheckRequest{
@@ -384,58 +1114,90 @@ func (r *checkResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		TableID: int(plan.TableID.ValueInt64()),
 	}
 	_, err = r.client.DeleteCheck(deleteRequest)
+	if isNotFoundError(err) {
+		tflog.Warn(ctx, "Check was deleted concurrently. Nothing to delete.", map[string]interface{}{
+			"table_id":        plan.TableID.ValueInt64(),
+			"check_static_id": plan.CheckStaticID.ValueInt64(),
+			"check_id":        existingCheck.CheckID,
+		})
+		return diags
+	}
 	if err != nil {
-		resp.Diagnostics.AddError(
+		diags.AddError(
 			"Error Deleting Check",
 			fmt.Sprintf("Could not delete check with ID %d for table ID %d, unexpected error: %s",
 				plan.CheckStaticID.ValueInt64(), plan.TableID.ValueInt64(), err.Error()),
 		)
-		return
 	}
+	return diags
 }
 
 func (r *checkResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	idParts := strings.Split(req.ID, ",")
-
-	tableID, err := strconv.Atoi(idParts[0])
-	if err != nil {
-		resp.Diagnostics.AddError("Error parsing table id from import identifier",
-			fmt.Sprintf("Could not convert %s to int", idParts[0]))
+	if req.ID == "" && req.Identity != nil {
+		// Imported with an `identity` in an import block. The identity is already in the response.
+		var identity checkResourceIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("table_id"), identity.TableID)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("check_static_id"), identity.CheckStaticID)...)
+		return
 	}
 
-	if isStaticIdImport(idParts) {
-		checkID, err := strconv.Atoi(idParts[1])
-		if err != nil {
-			resp.Diagnostics.AddError("Error parsing check id from import identifier",
-				fmt.Sprintf("Could not convert %s to int", idParts[1]))
-		}
-		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("table_id"), tableID)...)
-		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("check_static_id"), checkID)...)
-	} else if isRefImport(idParts) {
-		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("table_id"), tableID)...)
-		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("ref"), idParts[2])...)
-	} else {
+	importID, err := parseCheckImportID(req.ID)
+	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unexpected Import Identifier",
-			fmt.Sprintf("Expected import identifier with format: tableId,checkID or tableID,checkID,ref. Got: %q", req.ID),
+			fmt.Sprintf("Could not parse import identifier %q: %s", req.ID, err.Error()),
 		)
 		return
 	}
-}
 
-func isStaticIdImport(idParts []string) bool {
-	properLength := len(idParts) == 2 || len(idParts) == 3
-	hasTableIdAndCheckId := idParts[0] != "" && idParts[1] != ""
-	doesNotHaveCheckRef := true
-	if len(idParts) == 3 {
-		doesNotHaveCheckRef = idParts[2] == ""
+	tableID := importID.TableID
+	if importID.TableName != "" {
+		table, err := getTableInformation(r.client, importID.TableName)
+		if err == nil && (table == nil || table.ID == 0) {
+			err = fmt.Errorf("table not found")
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Importing Check",
+				fmt.Sprintf("Could not find table %s for import identifier %q. Unexpected error: %s",
+					importID.TableName, req.ID, err.Error()),
+			)
+			return
+		}
+		tableID = table.ID
+	}
+
+	checkStaticID := importID.CheckStaticID
+	if importID.URLCheckID != 0 {
+		// UI URLs may contain either the check ID or the static ID.
+		checks, err := getChecks(r.client, tableID)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Importing Check",
+				fmt.Sprintf("Could not fetch checks for table ID %d, unexpected error: %s", tableID, err.Error()),
+			)
+			return
+		}
+		for _, check := range checks.Checks {
+			if check.CheckStaticID == importID.URLCheckID || check.CheckID == importID.URLCheckID {
+				checkStaticID = check.CheckStaticID
+				break
+			}
+		}
+		if checkStaticID == 0 {
+			resp.Diagnostics.AddError(
+				"Error Importing Check",
+				fmt.Sprintf("Could not find a check with ID %d on table ID %d", importID.URLCheckID, tableID),
+			)
+			return
+		}
 	}
-	return properLength && hasTableIdAndCheckId && doesNotHaveCheckRef
-}
 
-func isRefImport(idParts []string) bool {
-	properLength := len(idParts) == 3
-	hasTableIdAndRef := idParts[0] != "" && idParts[2] != ""
-	doesNotHaveCheckId := idParts[1] == ""
-	return properLength && hasTableIdAndRef && doesNotHaveCheckId
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("table_id"), tableID)...)
+	if checkStaticID != 0 {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("check_static_id"), checkStaticID)...)
+	} else {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("ref"), importID.Ref)...)
+	}
 }
@@ -0,0 +1,100 @@
+package anomalo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+
+	"github.com/square/anomalo-go/anomalo"
+)
+
+// testCheckModel returns the state of a NullValues check configured with params_json.
+func testCheckModel(paramsJSON string) checkResourceModel {
+	return checkResourceModel{
+		TableID:               types.Int64Value(testTableID),
+		CheckType:             types.StringValue("NullValues"),
+		CheckStaticID:         types.Int64Value(70),
+		Ref:                   types.StringValue("nulls"),
+		Params:                types.MapNull(types.StringType),
+		ParamsJSON:            paramsJSONValue{StringValue: basetypes.NewStringValue(paramsJSON)},
+		IgnoreParams:          types.SetNull(types.StringType),
+		EffectiveParams:       types.MapNull(types.StringType),
+		DeletionProtection:    types.BoolNull(),
+		AdoptExisting:         types.BoolNull(),
+		PriorityLevel:         types.StringNull(),
+		Description:           types.StringNull(),
+		Enabled:               types.BoolNull(),
+		NotificationChannelID: types.Int64Null(),
+	}
+}
+
+func TestSetFromCheckNumericParams(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		configured string
+		fromAPI    interface{}
+		same       bool
+	}{
+		{"number returned as a string", `{"column_name":"id","max_null_ratio":0.001}`, "0.001", true},
+		{"number reformatted", `{"column_name":"id","max_null_ratio":0.001}`, "1e-3", true},
+		{"string configured as a number", `{"column_name":"id","max_null_ratio":"0.001"}`, "0.001", true},
+		{"number changed", `{"column_name":"id","max_null_ratio":0.001}`, "0.002", false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			prior := testCheckModel(tc.configured)
+			check := anomalo.Check{CheckID: 7, CheckStaticID: 70, Ref: "nulls"}
+			check.Config.Check = "NullValues"
+			check.Config.Params = map[string]interface{}{
+				"column_name":     "id",
+				"max_null_ratio":  tc.fromAPI,
+				"check_static_id": "70",
+				"ref":             "nulls",
+			}
+
+			read := prior
+			if diags := read.setFromCheck(&check); diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if kept := read.ParamsJSON.Equal(prior.ParamsJSON); kept != tc.same {
+				t.Errorf("expected params_json to be kept: %t, got %s", tc.same, read.ParamsJSON.ValueString())
+			}
+			if same := prior.sameCheck(context.Background(), read); same != tc.same {
+				t.Errorf("expected sameCheck to be %t after reading %s", tc.same, read.ParamsJSON.ValueString())
+			}
+		})
+	}
+}
+
+func TestPlanRecreationTableProtection(t *testing.T) {
+	ctx := context.Background()
+	state := testCheckModel(`{"column_name":"id","max_null_ratio":0.001}`)
+	plan := testCheckModel(`{"column_name":"id","max_null_ratio":0.002}`)
+
+	for _, tc := range []struct {
+		name                string
+		protectCheckHistory bool
+		tableProtected      bool
+		expectError         bool
+	}{
+		{"table protected", true, true, true},
+		{"table not protected", true, false, false},
+		{"protect_check_history disabled", false, true, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &checkResource{protectCheckHistory: tc.protectCheckHistory, tableProtections: newTableProtections()}
+			r.tableProtections.record(state.TableID, types.BoolValue(tc.tableProtected))
+
+			var diags diag.Diagnostics
+			r.planRecreation(ctx, plan, state, &diags)
+			if diags.HasError() != tc.expectError {
+				t.Errorf("expected an error: %t, got %v", tc.expectError, diags)
+			}
+			if !tc.expectError && !hasDiagnostic(diags, diag.SeverityWarning, "Check Update Replaces the Check ID") {
+				t.Errorf("expected a warning, got %v", diags)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package anomalo
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// UpgradeState migrates state from prior schema versions. Each upgrader converts directly to the current version.
+func (r *checkResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// Version 0 could store the check's ref in params, from before ref was a top level attribute.
+		0: {
+			PriorSchema:   checkResourceSchemaV1(),
+			StateUpgrader: upgradeCheckStateV0,
+		},
+		// Version 1 had no params_json, and stored numeric params in Go's float format (ex. 1e+06).
+		1: {
+			PriorSchema:   checkResourceSchemaV1(),
+			StateUpgrader: upgradeCheckStateV1,
+		},
+	}
+}
+
+// checkResourceModelV1 is checkResourceModel as of schema versions 0 & 1.
+type checkResourceModelV1 struct {
+	TableID            types.Int64  `tfsdk:"table_id"`
+	CheckType          types.String `tfsdk:"check_type"`
+	CheckStaticID      types.Int64  `tfsdk:"check_static_id"`
+	Ref                types.String `tfsdk:"ref"`
+	Params             types.Map    `tfsdk:"params"`
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
+}
+
+// checkResourceSchemaV1 is the schema of versions 0 & 1. Only attribute types matter for decoding prior state, and
+// they're the same in both versions.
+func checkResourceSchemaV1() *schema.Schema {
+	return &schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"check_static_id":     schema.Int64Attribute{Computed: true, Optional: true},
+			"table_id":            schema.Int64Attribute{Computed: true, Optional: true},
+			"check_type":          schema.StringAttribute{Required: true},
+			"ref":                 schema.StringAttribute{Computed: true, Optional: true},
+			"params":              schema.MapAttribute{Required: true, ElementType: types.StringType},
+			"deletion_protection": deletionProtectionAttribute(),
+		},
+	}
+}
+
+// upgradeCheckStateV0 moves `params["ref"]` into the top level ref. The top level ref takes precedence if both are
+// set, matching Create & Update.
+func upgradeCheckStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var state checkResourceModelV1
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := state.Params.Elements()
+	if legacyRef, ok := params["ref"].(types.String); ok {
+		if state.Ref.ValueString() == "" {
+			state.Ref = legacyRef
+		}
+		trimmed := make(map[string]attr.Value, len(params))
+		for key, val := range params {
+			if key != "ref" {
+				trimmed[key] = val
+			}
+		}
+		var diags diag.Diagnostics
+		state.Params, diags = types.MapValue(types.StringType, trimmed)
+		resp.Diagnostics.Append(diags...)
+	}
+
+	upgraded, diags := state.upgrade()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, upgraded)...)
+}
+
+func upgradeCheckStateV1(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var state checkResourceModelV1
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	upgraded, diags := state.upgrade()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, upgraded)...)
+}
+
+// goFloatExponent matches numbers formatted by Go's %v with an exponent, ex. 1e+06 or 2.5e-07.
+var goFloatExponent = regexp.MustCompile(`^-?[0-9](\.[0-9]+)?e[+-][0-9]{2,}$`)
+
+// upgrade converts version 1 state to the current version. params_json is null, and numeric params that Read used to
+// format with an exponent are rewritten as plain decimals, matching how Read formats them now.
+func (m checkResourceModelV1) upgrade() (checkResourceModel, diag.Diagnostics) {
+	upgraded := checkResourceModel{
+		TableID:            m.TableID,
+		CheckType:          m.CheckType,
+		CheckStaticID:      m.CheckStaticID,
+		Ref:                m.Ref,
+		Params:             m.Params,
+		ParamsJSON:         newParamsJSONNull(),
+		IgnoreParams:       types.SetNull(types.StringType),
+		EffectiveParams:    types.MapNull(types.StringType),
+		DeletionProtection: m.DeletionProtection,
+	}
+	if m.Params.IsNull() {
+		return upgraded, nil
+	}
+
+	params := make(map[string]attr.Value, len(m.Params.Elements()))
+	for key, val := range m.Params.Elements() {
+		params[key] = val
+		str, ok := val.(types.String)
+		if !ok || !goFloatExponent.MatchString(str.ValueString()) {
+			continue
+		}
+		if f, err := strconv.ParseFloat(str.ValueString(), 64); err == nil {
+			params[key] = types.StringValue(strconv.FormatFloat(f, 'f', -1, 64))
+		}
+	}
+	var diags diag.Diagnostics
+	upgraded.Params, diags = types.MapValue(types.StringType, params)
+	return upgraded, diags
+}
@@ -0,0 +1,212 @@
+package anomalo
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/square/anomalo-go/anomalo"
+)
+
+// Anomalo updates a check by creating its replacement and then deleting it, so an update can fail partway through and
+// leave zero or two checks with the check's static ID. Updates are verified by re-reading the table's checks. Reads
+// are retried in case Anomalo hasn't finished replacing the check, unless Anomalo rejected the update outright.
+var (
+	checkUpdateVerifyAttempts = 3
+	checkUpdateVerifyDelay    = 2 * time.Second
+)
+
+// checkUpdateResult is the state of a check in Anomalo after an update.
+type checkUpdateResult struct {
+	// old is the check that was updated, if it still exists.
+	old *anomalo.Check
+	// replacements are the other checks with the same static ID. There should be exactly one.
+	replacements []anomalo.Check
+	// refConflicts are checks with the updated check's ref, but a different static ID.
+	refConflicts []anomalo.Check
+}
+
+// readCheckUpdate reads the state of an updated check from the table's checks.
+func readCheckUpdate(client *anomalo.Client, tableID int, old *anomalo.Check, ref string) (checkUpdateResult, error) {
+	var result checkUpdateResult
+	checks, err := getChecks(client, tableID)
+	if err != nil {
+		return result, err
+	}
+	for i := range checks.Checks {
+		check := checks.Checks[i]
+		switch {
+		case check.CheckStaticID == old.CheckStaticID && check.CheckID == old.CheckID:
+			result.old = &check
+		case check.CheckStaticID == old.CheckStaticID:
+			result.replacements = append(result.replacements, check)
+		case ref != "" && check.Ref == ref:
+			result.refConflicts = append(result.refConflicts, check)
+		}
+	}
+	return result, nil
+}
+
+// verifyCheckUpdate checks that an update replaced the old check with exactly one new check, and returns the new
+// check. createErr is the error returned by the update request, if any. When the old check wasn't removed, it's
+// deleted. When the old check was removed without a replacement, it's restored. Returns nil if the update didn't
+// succeed.
+func (r *checkResource) verifyCheckUpdate(ctx context.Context, checkDescription string, old *anomalo.Check,
+	req createCheckRequest, createErr error) (*anomalo.Check, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	ref, _ := req.Params["ref"].(string)
+	if ref == "" {
+		ref = old.Ref
+	}
+
+	attempts := checkUpdateVerifyAttempts
+	if isRejectedError(createErr) {
+		// Anomalo didn't start replacing the check, so there's nothing to wait for.
+		attempts = 1
+	}
+	var result checkUpdateResult
+	var err error
+	for attempt := 1; ; attempt++ {
+		result, err = readCheckUpdate(r.client, req.TableID, old, ref)
+		if (err == nil && len(result.replacements) > 0) || attempt >= attempts {
+			break
+		}
+		tflog.Debug(ctx, "Check update isn't visible yet. Retrying.", map[string]interface{}{
+			"table_id":        req.TableID,
+			"check_static_id": old.CheckStaticID,
+			"attempt":         attempt,
+		})
+		select {
+		case <-ctx.Done():
+			err = ctx.Err()
+		case <-time.After(checkUpdateVerifyDelay):
+		}
+		if err != nil {
+			break
+		}
+	}
+	if err != nil {
+		diags.AddError(
+			"Error Verifying Check Update",
+			fmt.Sprintf("Could not read the checks of table ID %d to verify the update of %s: %s. Run `terraform "+
+				"plan` to compare the check in Anomalo with the configuration.%s", req.TableID, checkDescription,
+				err.Error(), createErrorDetail(createErr)),
+		)
+		return nil, diags
+	}
+
+	switch {
+	case len(result.replacements) == 0 && result.old != nil:
+		if createErr != nil {
+			diags.AddError(
+				"Error Updating Check",
+				fmt.Sprintf("Could not update %s, unexpected error: %s. The check in Anomalo is unchanged.",
+					checkDescription, createErr.Error()),
+			)
+		} else {
+			diags.AddError(
+				"Error Updating Check",
+				fmt.Sprintf("Anomalo accepted the update of %s, but the check still has check ID %d and its prior "+
+					"configuration. Run `terraform apply` again to retry.", checkDescription, old.CheckID),
+			)
+		}
+		return nil, diags
+	case len(result.replacements) == 0:
+		diags.Append(r.restoreCheck(req.TableID, checkDescription, old, createErr)...)
+		return nil, diags
+	case len(result.replacements) > 1:
+		checkIDs := make([]string, 0, len(result.replacements))
+		for _, check := range result.replacements {
+			checkIDs = append(checkIDs, strconv.Itoa(check.CheckID))
+		}
+		diags.AddError(
+			"Duplicate Checks",
+			fmt.Sprintf("After updating %s, Anomalo has %d new checks with static ID %d (check IDs %s). Delete all "+
+				"but one of them in Anomalo, then run `terraform apply` again.", checkDescription,
+				len(result.replacements), old.CheckStaticID, strings.Join(checkIDs, ", ")),
+		)
+		return nil, diags
+	}
+
+	updated := &result.replacements[0]
+	if result.old != nil {
+		// Anomalo created the replacement, but didn't delete the old check.
+		_, err := r.client.DeleteCheck(anomalo.DeleteCheckRequest{CheckID: old.CheckID, TableID: req.TableID})
+		if err != nil && !isNotFoundError(err) {
+			diags.AddError(
+				"Duplicate Checks",
+				fmt.Sprintf("Anomalo created the update of %s as check ID %d, but didn't remove the prior check ID "+
+					"%d, which couldn't be deleted: %s. Delete check ID %d in Anomalo, then run `terraform apply` "+
+					"again.", checkDescription, updated.CheckID, old.CheckID, err.Error(), old.CheckID),
+			)
+			return nil, diags
+		}
+		tflog.Warn(ctx, "Deleted the prior check that Anomalo left behind after an update.", map[string]interface{}{
+			"table_id": req.TableID,
+			"check_id": old.CheckID,
+		})
+	}
+	if createErr != nil {
+		tflog.Warn(ctx, "Anomalo returned an error for a check update that succeeded.", map[string]interface{}{
+			"table_id": req.TableID,
+			"check_id": updated.CheckID,
+			"error":    createErr.Error(),
+		})
+	}
+	for _, check := range result.refConflicts {
+		diags.AddWarning(
+			"Duplicate Check Ref",
+			fmt.Sprintf("Check ID %d (static ID %d) on table ID %d also has ref %q, which should be unique. It may be "+
+				"left over from an earlier failed apply. Delete it in Anomalo, or import it into its own resource.",
+				check.CheckID, check.CheckStaticID, req.TableID, check.Ref),
+		)
+	}
+	return updated, diags
+}
+
+// restoreCheck recreates the old check with its prior configuration, after an update removed it without creating a
+// replacement. The update always fails, so the returned diagnostics always contain an error.
+func (r *checkResource) restoreCheck(tableID int, checkDescription string, old *anomalo.Check, createErr error) diag.Diagnostics {
+	var diags diag.Diagnostics
+	params := map[string]interface{}{}
+	for key, val := range old.Config.Params {
+		if val != nil {
+			params[key] = val
+		}
+	}
+	params["check_static_id"] = strconv.Itoa(old.CheckStaticID)
+	if old.Ref != "" {
+		params["ref"] = old.Ref
+	}
+
+	_, err := createCheck(r.client, createCheckRequest{TableID: tableID, CheckType: old.Config.Check, Params: params})
+	if err != nil {
+		diags.AddError(
+			"Error Updating Check",
+			fmt.Sprintf("Updating %s removed the check from Anomalo without creating its replacement, and restoring "+
+				"its prior configuration failed: %s. Run `terraform apply` again to recreate it.%s",
+				checkDescription, err.Error(), createErrorDetail(createErr)),
+		)
+		return diags
+	}
+	diags.AddError(
+		"Error Updating Check",
+		fmt.Sprintf("Updating %s removed the check from Anomalo without creating its replacement. Its prior "+
+			"configuration was restored. Run `terraform apply` again to retry the update.%s", checkDescription,
+			createErrorDetail(createErr)),
+	)
+	return diags
+}
+
+// createErrorDetail describes the error returned by a create_check request, for appending to a diagnostic.
+func createErrorDetail(createErr error) string {
+	if createErr == nil {
+		return ""
+	}
+	return fmt.Sprintf(" The update request returned: %s", createErr.Error())
+}
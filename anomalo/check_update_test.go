@@ -0,0 +1,381 @@
+package anomalo
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/square/anomalo-go/anomalo"
+)
+
+const testTableID = 12
+
+// fakeAnomalo fakes the check endpoints used by check updates. onCreate decides what a create_check request does to
+// the table's checks, and which status code it returns.
+type fakeAnomalo struct {
+	mu          sync.Mutex
+	checks      []anomalo.Check
+	nextCheckID int
+	onCreate    func(f *fakeAnomalo, req createCheckRequest) int
+	gets        int
+	creates     []createCheckRequest
+	deletes     []int
+}
+
+func newFakeAnomalo(t *testing.T, checks ...anomalo.Check) (*fakeAnomalo, *checkResource) {
+	f := &fakeAnomalo{checks: checks, nextCheckID: 100}
+	server := httptest.NewServer(f)
+	t.Cleanup(server.Close)
+
+	delay := checkUpdateVerifyDelay
+	checkUpdateVerifyDelay = time.Millisecond
+	t.Cleanup(func() { checkUpdateVerifyDelay = delay })
+
+	return f, &checkResource{client: &anomalo.Client{Host: server.URL, Token: "token"}}
+}
+
+func (f *fakeAnomalo) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch strings.TrimPrefix(req.URL.Path, "/api/public/v1/") {
+	case "get_checks_for_table":
+		f.gets++
+		_ = json.NewEncoder(w).Encode(anomalo.GetChecksResponse{Checks: f.checks})
+	case "create_check":
+		var createReq createCheckRequest
+		if err := json.NewDecoder(req.Body).Decode(&createReq); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		f.creates = append(f.creates, createReq)
+		status := f.onCreate(f, createReq)
+		if status != http.StatusOK {
+			http.Error(w, "create_check failed", status)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(anomalo.CreateCheckResponse{CheckID: f.nextCheckID})
+	case "delete_check":
+		var deleteReq anomalo.DeleteCheckRequest
+		if err := json.NewDecoder(req.Body).Decode(&deleteReq); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		f.deletes = append(f.deletes, deleteReq.CheckID)
+		f.remove(deleteReq.CheckID)
+		_ = json.NewEncoder(w).Encode(anomalo.DeleteCheckResponse{DeletedCount: 1})
+	default:
+		http.NotFound(w, req)
+	}
+}
+
+// add creates a check from a create_check request, as Anomalo does.
+func (f *fakeAnomalo) add(req createCheckRequest) {
+	f.nextCheckID++
+	staticID, _ := strconv.Atoi(paramString(req.Params["check_static_id"]))
+	ref, _ := req.Params["ref"].(string)
+	f.checks = append(f.checks, testCheck(f.nextCheckID, staticID, ref, req.Params))
+}
+
+func (f *fakeAnomalo) remove(checkID int) {
+	for i, check := range f.checks {
+		if check.CheckID == checkID {
+			f.checks = append(f.checks[:i], f.checks[i+1:]...)
+			return
+		}
+	}
+}
+
+func testCheck(checkID, staticID int, ref string, params map[string]interface{}) anomalo.Check {
+	check := anomalo.Check{CheckID: checkID, CheckStaticID: staticID, Ref: ref}
+	check.Config.Check = "RowCount"
+	check.Config.Params = params
+	return check
+}
+
+// rowCountModel returns the state of check, a RowCount check configured with params.
+func rowCountModel(check anomalo.Check, minRowCount string) checkResourceModel {
+	return checkResourceModel{
+		TableID:       types.Int64Value(testTableID),
+		CheckType:     types.StringValue("RowCount"),
+		CheckStaticID: types.Int64Value(int64(check.CheckStaticID)),
+		Ref:           types.StringValue(check.Ref),
+		Params: types.MapValueMust(types.StringType, map[string]attr.Value{
+			"min_row_count": types.StringValue(minRowCount),
+		}),
+		ParamsJSON:            newParamsJSONNull(),
+		IgnoreParams:          types.SetNull(types.StringType),
+		EffectiveParams:       types.MapNull(types.StringType),
+		DeletionProtection:    types.BoolNull(),
+		AdoptExisting:         types.BoolNull(),
+		PriorityLevel:         types.StringNull(),
+		Description:           types.StringNull(),
+		Enabled:               types.BoolNull(),
+		NotificationChannelID: types.Int64Null(),
+	}
+}
+
+// updateCheck runs Update with the provided prior state & plan, and returns the resulting state.
+func updateCheck(ctx context.Context, t *testing.T, r *checkResource, prior, planned checkResourceModel) (
+	checkResourceModel, diag.Diagnostics) {
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	req := resource.UpdateRequest{
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema},
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+	if diags := req.Plan.Set(ctx, planned); diags.HasError() {
+		t.Fatalf("unable to set the plan: %v", diags)
+	}
+	if diags := req.State.Set(ctx, prior); diags.HasError() {
+		t.Fatalf("unable to set the state: %v", diags)
+	}
+	resp := resource.UpdateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Update(ctx, req, &resp)
+
+	var state checkResourceModel
+	if diags := resp.State.Get(ctx, &state); diags.HasError() {
+		t.Fatalf("unable to get the state: %v", diags)
+	}
+	return state, resp.Diagnostics
+}
+
+func minRowCount(m checkResourceModel) string {
+	value, _ := m.Params.Elements()["min_row_count"].(types.String)
+	return value.ValueString()
+}
+
+func hasDiagnostic(diags diag.Diagnostics, severity diag.Severity, summary string) bool {
+	for _, d := range diags {
+		if d.Severity() == severity && d.Summary() == summary {
+			return true
+		}
+	}
+	return false
+}
+
+func TestCheckUpdate(t *testing.T) {
+	ctx := context.Background()
+	old := testCheck(7, 70, "rows", map[string]interface{}{"min_row_count": "1"})
+	prior := rowCountModel(old, "1")
+	planned := rowCountModel(old, "10")
+
+	// expectPriorState checks that a failed update wrote the prior state back.
+	expectPriorState := func(t *testing.T, state checkResourceModel) {
+		if !state.CheckStaticID.Equal(prior.CheckStaticID) || minRowCount(state) != "1" {
+			t.Errorf("expected the prior state to be kept, got static ID %s, min_row_count %q",
+				state.CheckStaticID, minRowCount(state))
+		}
+	}
+
+	t.Run("replaced", func(t *testing.T) {
+		f, r := newFakeAnomalo(t, old)
+		f.onCreate = func(f *fakeAnomalo, req createCheckRequest) int {
+			f.remove(old.CheckID)
+			f.add(req)
+			return http.StatusOK
+		}
+
+		state, diags := updateCheck(ctx, t, r, prior, planned)
+		if diags.HasError() || state.CheckStaticID.ValueInt64() != int64(old.CheckStaticID) || minRowCount(state) != "10" {
+			t.Fatalf("expected the replacement, got %+v, %v", state, diags)
+		}
+		if len(f.creates) != 1 || f.creates[0].Params["check_static_id"] != strconv.Itoa(old.CheckStaticID) {
+			t.Errorf("expected one create that keeps the static ID, got %+v", f.creates)
+		}
+		if len(f.deletes) != 0 {
+			t.Errorf("expected no deletes, got %v", f.deletes)
+		}
+	})
+
+	t.Run("unchanged check isn't recreated", func(t *testing.T) {
+		f, r := newFakeAnomalo(t, old)
+		protected := prior
+		protected.DeletionProtection = types.BoolValue(true)
+
+		state, diags := updateCheck(ctx, t, r, prior, protected)
+		if diags.HasError() || !state.DeletionProtection.ValueBool() {
+			t.Fatalf("expected deletion_protection to be updated, got %+v, %v", state, diags)
+		}
+		if f.gets != 0 || len(f.creates) != 0 {
+			t.Errorf("expected no requests, got %d reads, %d creates", f.gets, len(f.creates))
+		}
+	})
+
+	t.Run("check no longer exists", func(t *testing.T) {
+		f, r := newFakeAnomalo(t)
+
+		state, diags := updateCheck(ctx, t, r, prior, planned)
+		if !hasDiagnostic(diags, diag.SeverityError, "Error Updating Check") ||
+			!strings.Contains(diags.Errors()[0].Detail(), "no longer exists") {
+			t.Fatalf("expected a missing check error, got %v", diags)
+		}
+		if len(f.creates) != 0 {
+			t.Errorf("expected no creates, got %d", len(f.creates))
+		}
+		expectPriorState(t, state)
+	})
+
+	t.Run("create fails and the old check remains", func(t *testing.T) {
+		f, r := newFakeAnomalo(t, old)
+		f.onCreate = func(*fakeAnomalo, createCheckRequest) int { return http.StatusInternalServerError }
+
+		state, diags := updateCheck(ctx, t, r, prior, planned)
+		if !hasDiagnostic(diags, diag.SeverityError, "Error Updating Check") {
+			t.Fatalf("expected an update error, got %v", diags)
+		}
+		if !strings.Contains(diags.Errors()[0].Detail(), "unchanged") {
+			t.Errorf("expected the error to say the check is unchanged, got %q", diags.Errors()[0].Detail())
+		}
+		// One read to find the check, then one per verification attempt.
+		if f.gets != 1+checkUpdateVerifyAttempts {
+			t.Errorf("expected %d reads for a failure that may be retried, got %d", 1+checkUpdateVerifyAttempts, f.gets)
+		}
+		expectPriorState(t, state)
+	})
+
+	t.Run("create is rejected", func(t *testing.T) {
+		f, r := newFakeAnomalo(t, old)
+		f.onCreate = func(*fakeAnomalo, createCheckRequest) int { return http.StatusBadRequest }
+
+		state, diags := updateCheck(ctx, t, r, prior, planned)
+		if !hasDiagnostic(diags, diag.SeverityError, "Error Updating Check") {
+			t.Fatalf("expected an update error, got %v", diags)
+		}
+		if f.gets != 2 {
+			t.Errorf("expected a single verification read after a rejected update, got %d reads", f.gets)
+		}
+		expectPriorState(t, state)
+	})
+
+	t.Run("old check removed without a replacement", func(t *testing.T) {
+		f, r := newFakeAnomalo(t, old)
+		f.onCreate = func(f *fakeAnomalo, req createCheckRequest) int {
+			if len(f.creates) == 1 {
+				f.remove(old.CheckID)
+				return http.StatusInternalServerError
+			}
+			// The restore.
+			f.add(req)
+			return http.StatusOK
+		}
+
+		state, diags := updateCheck(ctx, t, r, prior, planned)
+		if !hasDiagnostic(diags, diag.SeverityError, "Error Updating Check") {
+			t.Fatalf("expected an update error, got %v", diags)
+		}
+		if !strings.Contains(diags.Errors()[0].Detail(), "prior configuration was restored") {
+			t.Errorf("expected the check to be restored, got %q", diags.Errors()[0].Detail())
+		}
+		if len(f.creates) != 2 || len(f.checks) != 1 {
+			t.Fatalf("expected the update & a restore, leaving one check. Got %d creates, %d checks",
+				len(f.creates), len(f.checks))
+		}
+		restored := f.checks[0]
+		if restored.CheckStaticID != old.CheckStaticID || restored.Ref != old.Ref ||
+			paramString(restored.Config.Params["min_row_count"]) != "1" {
+			t.Errorf("expected the prior configuration to be restored, got %+v", restored)
+		}
+		expectPriorState(t, state)
+	})
+
+	t.Run("old check not deleted", func(t *testing.T) {
+		f, r := newFakeAnomalo(t, old)
+		f.onCreate = func(f *fakeAnomalo, req createCheckRequest) int {
+			f.add(req)
+			return http.StatusOK
+		}
+
+		state, diags := updateCheck(ctx, t, r, prior, planned)
+		if diags.HasError() || minRowCount(state) != "10" {
+			t.Fatalf("expected the replacement, got %+v, %v", state, diags)
+		}
+		if len(f.deletes) != 1 || f.deletes[0] != old.CheckID {
+			t.Errorf("expected the old check to be deleted, got deletes %v", f.deletes)
+		}
+		if len(f.checks) != 1 {
+			t.Errorf("expected one check left, got %d", len(f.checks))
+		}
+	})
+
+	t.Run("two replacements", func(t *testing.T) {
+		f, r := newFakeAnomalo(t, old)
+		f.onCreate = func(f *fakeAnomalo, req createCheckRequest) int {
+			f.remove(old.CheckID)
+			f.add(req)
+			f.add(req)
+			return http.StatusOK
+		}
+
+		state, diags := updateCheck(ctx, t, r, prior, planned)
+		if !hasDiagnostic(diags, diag.SeverityError, "Duplicate Checks") {
+			t.Fatalf("expected a duplicate checks error, got %v", diags)
+		}
+		if len(f.deletes) != 0 {
+			t.Errorf("expected duplicates to be left for the user to resolve, got deletes %v", f.deletes)
+		}
+		expectPriorState(t, state)
+	})
+
+	t.Run("ref conflict", func(t *testing.T) {
+		conflict := testCheck(8, 80, old.Ref, nil)
+		f, r := newFakeAnomalo(t, old, conflict)
+		f.onCreate = func(f *fakeAnomalo, req createCheckRequest) int {
+			f.remove(old.CheckID)
+			f.add(req)
+			return http.StatusOK
+		}
+
+		state, diags := updateCheck(ctx, t, r, prior, planned)
+		if diags.HasError() || minRowCount(state) != "10" {
+			t.Fatalf("expected the replacement, got %+v, %v", state, diags)
+		}
+		if !hasDiagnostic(diags, diag.SeverityWarning, "Duplicate Check Ref") {
+			t.Errorf("expected a duplicate ref warning, got %v", diags)
+		}
+	})
+
+	t.Run("create errors but the update succeeded", func(t *testing.T) {
+		f, r := newFakeAnomalo(t, old)
+		f.onCreate = func(f *fakeAnomalo, req createCheckRequest) int {
+			f.remove(old.CheckID)
+			f.add(req)
+			return http.StatusGatewayTimeout
+		}
+
+		state, diags := updateCheck(ctx, t, r, prior, planned)
+		if diags.HasError() || state.CheckStaticID.ValueInt64() != int64(old.CheckStaticID) || minRowCount(state) != "10" {
+			t.Fatalf("expected the replacement, got %+v, %v", state, diags)
+		}
+	})
+
+	t.Run("context canceled while waiting", func(t *testing.T) {
+		f, r := newFakeAnomalo(t, old)
+		f.onCreate = func(*fakeAnomalo, createCheckRequest) int { return http.StatusInternalServerError }
+		checkUpdateVerifyDelay = time.Hour
+		canceled, cancel := context.WithCancel(ctx)
+		cancel()
+
+		state, diags := updateCheck(canceled, t, r, prior, planned)
+		if !hasDiagnostic(diags, diag.SeverityError, "Error Verifying Check Update") {
+			t.Fatalf("expected a verification error, got %v", diags)
+		}
+		if f.gets != 2 || len(f.creates) != 1 {
+			t.Errorf("expected no retries or restores after cancellation, got %d reads, %d creates", f.gets,
+				len(f.creates))
+		}
+		expectPriorState(t, state)
+	})
+}
@@ -0,0 +1,181 @@
+package anomalo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/square/anomalo-go/anomalo"
+)
+
+// columnParams are check params whose values name columns of the check's table. Values are a single column name, a
+// comma-separated list of names, or a JSON list of names.
+var columnParams = map[string]bool{
+	"column_name":        true,
+	"column_names":       true,
+	"columns":            true,
+	"time_column":        true,
+	"time_column_target": true,
+	"segment_columns":    true,
+}
+
+// parseColumnParam returns the column names in the value of a column param.
+func parseColumnParam(value string) []string {
+	value = strings.TrimSpace(value)
+	var names []string
+	if strings.HasPrefix(value, "[") && json.Unmarshal([]byte(value), &names) == nil {
+		return names
+	}
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// tableColumnCache caches the column names of each table for the life of the provider process, so a plan with many
+// resources on the same table fetches its columns once. Each table has its own entry, so a slow fetch only holds up
+// resources on the same table.
+type tableColumnCache struct {
+	client  *anomalo.Client
+	mu      sync.Mutex
+	entries map[int]*tableColumnsEntry
+}
+
+// tableColumnsEntry holds the columns of one table. Its lock is held while they're fetched. Failed fetches aren't
+// cached, so they're retried by the next resource.
+type tableColumnsEntry struct {
+	mu      sync.Mutex
+	fetched bool
+	columns []string
+}
+
+func newTableColumnCache(client *anomalo.Client) *tableColumnCache {
+	return &tableColumnCache{client: client, entries: map[int]*tableColumnsEntry{}}
+}
+
+func (c *tableColumnCache) get(tableID int) ([]string, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[tableID]
+	if !ok {
+		entry = &tableColumnsEntry{}
+		c.entries[tableID] = entry
+	}
+	c.mu.Unlock()
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+	if entry.fetched {
+		return entry.columns, nil
+	}
+
+	tableColumns, err := getTableColumns(c.client, tableID)
+	if err != nil {
+		return nil, err
+	}
+	columns := make([]string, 0, len(tableColumns))
+	for _, column := range tableColumns {
+		columns = append(columns, column.Name)
+	}
+	entry.columns, entry.fetched = columns, true
+	return columns, nil
+}
+
+// validateColumns adds an error at attributePath for each name that isn't a column of the table. Validation is
+// skipped if the columns can't be fetched or aren't known yet (ex. the table hasn't been profiled), because Anomalo
+// still reports missing columns when the check runs.
+func (c *tableColumnCache) validateColumns(ctx context.Context, tableID int, tableDescription string,
+	attributePath path.Path, names []string, diags *diag.Diagnostics) {
+	if c == nil || tableID <= 0 || len(names) == 0 {
+		return
+	}
+
+	columns, err := c.get(tableID)
+	if err != nil || len(columns) == 0 {
+		tflog.Warn(ctx, "Unable to fetch table columns. Skipping column validation.", map[string]interface{}{
+			"table_id": tableID,
+			"error":    fmt.Sprintf("%v", err),
+		})
+		return
+	}
+
+	known := make(map[string]bool, len(columns))
+	for _, column := range columns {
+		known[strings.ToLower(column)] = true
+	}
+	for _, name := range names {
+		if known[strings.ToLower(name)] {
+			continue
+		}
+		detail := fmt.Sprintf("Column %q does not exist in %s.", name, tableDescription)
+		if suggestions := closestNames(name, columns); len(suggestions) > 0 {
+			detail += fmt.Sprintf(" Did you mean %s?", strings.Join(quoteAll(suggestions), " or "))
+		}
+		diags.AddAttributeError(attributePath, "Unknown Column", detail)
+	}
+}
+
+// closestNames returns up to 3 candidates (ex. column names) that are within a few edits of name, closest first.
+// Case is ignored.
+func closestNames(name string, candidates []string) []string {
+	maxDistance := len(name) / 3
+	if maxDistance < 2 {
+		maxDistance = 2
+	}
+
+	type match struct {
+		name     string
+		distance int
+	}
+	var matches []match
+	for _, candidate := range candidates {
+		if d := levenshtein(strings.ToLower(name), strings.ToLower(candidate)); d <= maxDistance {
+			matches = append(matches, match{candidate, d})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].distance < matches[j].distance })
+
+	var closest []string
+	for i := 0; i < len(matches) && i < 3; i++ {
+		closest = append(closest, matches[i].name)
+	}
+	return closest
+}
+
+// levenshtein returns the number of single-character insertions, deletions, or substitutions between a and b.
+func levenshtein(a, b string) int {
+	ar, br := []rune(a), []rune(b)
+	prev := make([]int, len(br)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ar); i++ {
+		curr := make([]int, len(br)+1)
+		curr[0] = i
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev = curr
+	}
+	return prev[len(br)]
+}
+
+func quoteAll(values []string) []string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = fmt.Sprintf("%q", value)
+	}
+	return quoted
+}
@@ -0,0 +1,78 @@
+package anomalo
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/square/anomalo-go/anomalo"
+)
+
+func TestTableColumnCache(t *testing.T) {
+	slow := make(chan struct{})
+	var fetches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		fetches.Add(1)
+		switch req.URL.Query().Get("table_id") {
+		case "1":
+			<-slow
+		case "3":
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(getTableColumnsResponse{Columns: []tableColumn{{Name: "id"}}})
+	}))
+	t.Cleanup(server.Close)
+	t.Cleanup(func() {
+		select {
+		case <-slow:
+		default:
+			close(slow)
+		}
+	})
+	cache := newTableColumnCache(&anomalo.Client{Host: server.URL, Token: "token"})
+
+	slowDone := make(chan error, 1)
+	go func() {
+		_, err := cache.get(1)
+		slowDone <- err
+	}()
+
+	// Another table isn't held up by the slow fetch.
+	fast := make(chan []string, 1)
+	go func() {
+		columns, _ := cache.get(2)
+		fast <- columns
+	}()
+	select {
+	case columns := <-fast:
+		if len(columns) != 1 || columns[0] != "id" {
+			t.Errorf("expected the table's columns, got %v", columns)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("fetching another table's columns waited for the slow fetch")
+	}
+
+	close(slow)
+	if err := <-slowDone; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Columns are fetched once per table, but failures are retried.
+	before := fetches.Load()
+	if _, err := cache.get(1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := cache.get(3); err == nil {
+		t.Fatal("expected an error")
+	}
+	if _, err := cache.get(3); err == nil {
+		t.Fatal("expected an error")
+	}
+	if fetched := fetches.Load() - before; fetched != 2 {
+		t.Errorf("expected only the failed fetches to be repeated, got %d fetches", fetched)
+	}
+}
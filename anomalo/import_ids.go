@@ -0,0 +1,258 @@
+package anomalo
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const tableImportFormats = "Accepted formats:\n" +
+	"  - a numeric Anomalo table ID. Ex `1234`\n" +
+	"  - a fully qualified table name. Ex `warehouse_name.schema_name.table_name`\n" +
+	"  - a warehouse ID & table name. Ex `12:schema_name.table_name`\n" +
+	"  - an Anomalo UI table URL. Ex `https://anomalo.example.com/dashboard/tables/1234`"
+
+// tableImportID is a parsed `anomalo_table` import identifier. Exactly one of TableID or TableName is set.
+// WarehouseID is only set alongside TableName.
+type tableImportID struct {
+	TableID     int
+	WarehouseID int
+	TableName   string
+}
+
+// parseTableImportID parses any of the identifier formats listed in tableImportFormats. Errors list the formats.
+func parseTableImportID(id string) (tableImportID, error) {
+	importID, err := matchTableImportID(id)
+	if err != nil {
+		return tableImportID{}, fmt.Errorf("%w.\n%s", err, tableImportFormats)
+	}
+	return importID, nil
+}
+
+func matchTableImportID(id string) (tableImportID, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return tableImportID{}, fmt.Errorf("import identifier is empty")
+	}
+
+	if tableID, err := strconv.Atoi(id); err == nil {
+		if tableID <= 0 {
+			return tableImportID{}, fmt.Errorf("table ID must be positive, got %d", tableID)
+		}
+		return tableImportID{TableID: tableID}, nil
+	}
+
+	if strings.HasPrefix(id, "http://") || strings.HasPrefix(id, "https://") {
+		tableID, err := tableIDFromURL(id)
+		if err != nil {
+			return tableImportID{}, err
+		}
+		return tableImportID{TableID: tableID}, nil
+	}
+
+	if warehouse, name, ok := strings.Cut(id, ":"); ok {
+		warehouseID, err := strconv.Atoi(strings.TrimSpace(warehouse))
+		if err != nil || warehouseID <= 0 {
+			return tableImportID{}, fmt.Errorf("could not parse warehouse ID %q", warehouse)
+		}
+		if len(splitTableName(name)) != 2 {
+			return tableImportID{}, fmt.Errorf("expected schema_name.table_name after the warehouse ID, got %q", name)
+		}
+		return tableImportID{WarehouseID: warehouseID, TableName: canonicalTableName(name)}, nil
+	}
+
+	parts := splitTableName(id)
+	if len(parts) < 3 {
+		return tableImportID{}, fmt.Errorf("table names must include the warehouse, schema, and table. Got %q", id)
+	}
+	for _, part := range parts {
+		if part == "" {
+			return tableImportID{}, fmt.Errorf("table name %q has an empty identifier", id)
+		}
+	}
+	return tableImportID{TableName: canonicalTableName(id)}, nil
+}
+
+// tableIDFromURL extracts the table ID from an Anomalo UI URL. The ID is either the path segment following
+// `tables`/`table`, or a `table_id`/`tableId` query parameter.
+func tableIDFromURL(rawURL string) (int, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return 0, fmt.Errorf("could not parse URL %q: %w", rawURL, err)
+	}
+
+	for _, key := range []string{"table_id", "tableId"} {
+		if value := u.Query().Get(key); value != "" {
+			if tableID, err := strconv.Atoi(value); err == nil && tableID > 0 {
+				return tableID, nil
+			}
+		}
+	}
+
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i := 0; i+1 < len(segments); i++ {
+		if segments[i] != "tables" && segments[i] != "table" {
+			continue
+		}
+		if tableID, err := strconv.Atoi(segments[i+1]); err == nil && tableID > 0 {
+			return tableID, nil
+		}
+	}
+
+	return 0, fmt.Errorf("could not find a table ID in URL %q", rawURL)
+}
+
+const checkImportFormats = "Accepted formats:\n" +
+	"  - table ID & check static ID. Ex `1234,56`\n" +
+	"  - table ID & check ref. Ex `1234,,my_check_ref` (note the double comma)\n" +
+	"  - fully qualified table name & check ref. Ex `warehouse_name.schema_name.table_name/my_check_ref`\n" +
+	"  - fully qualified table name & check static ID. Ex `warehouse_name.schema_name.table_name#56`\n" +
+	"  - an Anomalo UI check URL. Ex `https://anomalo.example.com/dashboard/tables/1234/checks/56`"
+
+// checkImportID is a parsed `anomalo_check` import identifier. Exactly one of TableID or TableName identifies the
+// table. Exactly one of CheckStaticID, Ref, or URLCheckID identifies the check.
+type checkImportID struct {
+	TableID       int
+	TableName     string
+	CheckStaticID int
+	Ref           string
+	// URLCheckID is a check ID found in an Anomalo UI URL. It may be either the check's ID or its static ID.
+	URLCheckID int
+}
+
+// parseCheckImportID parses any of the identifier formats listed in checkImportFormats. Errors list the formats.
+func parseCheckImportID(id string) (checkImportID, error) {
+	importID, err := matchCheckImportID(id)
+	if err != nil {
+		return checkImportID{}, fmt.Errorf("%w.\n%s", err, checkImportFormats)
+	}
+	return importID, nil
+}
+
+func matchCheckImportID(id string) (checkImportID, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return checkImportID{}, fmt.Errorf("import identifier is empty")
+	}
+
+	if strings.HasPrefix(id, "http://") || strings.HasPrefix(id, "https://") {
+		return checkImportIDFromURL(id)
+	}
+
+	// Refs may contain commas, so only identifiers that start with a table ID use the comma formats.
+	if tableID, _, ok := strings.Cut(id, ","); ok && isDigits(tableID) {
+		return parseCommaCheckImportID(id)
+	}
+
+	if tableName, ref, ok := strings.Cut(id, "/"); ok {
+		if ref == "" {
+			return checkImportID{}, fmt.Errorf("check ref is empty")
+		}
+		tableID, err := matchTableImportID(tableName)
+		if err != nil || tableID.TableName == "" || tableID.WarehouseID != 0 {
+			return checkImportID{}, fmt.Errorf("expected a fully qualified table name before '/', got %q", tableName)
+		}
+		return checkImportID{TableName: tableID.TableName, Ref: ref}, nil
+	}
+
+	if tableName, staticID, ok := strings.Cut(id, "#"); ok {
+		checkStaticID, err := strconv.Atoi(staticID)
+		if err != nil || checkStaticID == 0 {
+			return checkImportID{}, fmt.Errorf("could not parse check static ID %q", staticID)
+		}
+		tableID, err := matchTableImportID(tableName)
+		if err != nil || tableID.TableName == "" || tableID.WarehouseID != 0 {
+			return checkImportID{}, fmt.Errorf("expected a fully qualified table name before '#', got %q", tableName)
+		}
+		return checkImportID{TableName: tableID.TableName, CheckStaticID: checkStaticID}, nil
+	}
+
+	return checkImportID{}, fmt.Errorf("unrecognized format")
+}
+
+// isDigits reports whether s is a non-empty string of ASCII digits.
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// parseCommaCheckImportID parses the `table_id,check_static_id` and `table_id,,check_ref` formats.
+func parseCommaCheckImportID(id string) (checkImportID, error) {
+	// The ref is last, and may itself contain commas.
+	idParts := strings.SplitN(id, ",", 3)
+
+	tableID, err := strconv.Atoi(idParts[0])
+	if err != nil || tableID <= 0 {
+		return checkImportID{}, fmt.Errorf("could not parse table ID %q", idParts[0])
+	}
+
+	staticID := idParts[1]
+	ref := ""
+	if len(idParts) == 3 {
+		ref = idParts[2]
+	}
+
+	switch {
+	case staticID != "" && ref == "":
+		checkStaticID, err := strconv.Atoi(staticID)
+		if err != nil || checkStaticID == 0 {
+			return checkImportID{}, fmt.Errorf("could not parse check static ID %q", staticID)
+		}
+		return checkImportID{TableID: tableID, CheckStaticID: checkStaticID}, nil
+	case staticID == "" && ref != "":
+		return checkImportID{TableID: tableID, Ref: ref}, nil
+	case staticID != "" && ref != "":
+		return checkImportID{}, fmt.Errorf("provide a check static ID or a check ref, not both")
+	default:
+		return checkImportID{}, fmt.Errorf("a check static ID or a check ref is required")
+	}
+}
+
+// checkImportIDFromURL extracts the table & check IDs from an Anomalo UI URL. The check ID is either the path segment
+// following `checks`/`check`, or a `check_id`/`checkId`/`check_static_id`/`checkStaticId` query parameter.
+func checkImportIDFromURL(rawURL string) (checkImportID, error) {
+	tableID, err := tableIDFromURL(rawURL)
+	if err != nil {
+		return checkImportID{}, err
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return checkImportID{}, fmt.Errorf("could not parse URL %q: %w", rawURL, err)
+	}
+
+	for _, key := range []string{"check_static_id", "checkStaticId"} {
+		if value := u.Query().Get(key); value != "" {
+			if checkStaticID, err := strconv.Atoi(value); err == nil && checkStaticID != 0 {
+				return checkImportID{TableID: tableID, CheckStaticID: checkStaticID}, nil
+			}
+		}
+	}
+	for _, key := range []string{"check_id", "checkId"} {
+		if value := u.Query().Get(key); value != "" {
+			if checkID, err := strconv.Atoi(value); err == nil && checkID != 0 {
+				return checkImportID{TableID: tableID, URLCheckID: checkID}, nil
+			}
+		}
+	}
+
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i := 0; i+1 < len(segments); i++ {
+		if segments[i] != "checks" && segments[i] != "check" {
+			continue
+		}
+		if checkID, err := strconv.Atoi(segments[i+1]); err == nil && checkID != 0 {
+			return checkImportID{TableID: tableID, URLCheckID: checkID}, nil
+		}
+	}
+
+	return checkImportID{}, fmt.Errorf("could not find a check ID in URL %q", rawURL)
+}
@@ -0,0 +1,143 @@
+package anomalo
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseTableImportID(t *testing.T) {
+	for _, tc := range []struct {
+		id       string
+		expected tableImportID
+	}{
+		{"1234", tableImportID{TableID: 1234}},
+		{" 1234 ", tableImportID{TableID: 1234}},
+		{"warehouse.schema.table", tableImportID{TableName: "warehouse.schema.table"}},
+		{"warehouse . schema . table", tableImportID{TableName: "warehouse.schema.table"}},
+		{"12:schema.table", tableImportID{WarehouseID: 12, TableName: "schema.table"}},
+		{"https://anomalo.example.com/dashboard/tables/1234", tableImportID{TableID: 1234}},
+		{"https://anomalo.example.com/dashboard/tables/1234/", tableImportID{TableID: 1234}},
+		{"https://anomalo.example.com/dashboard/tables/1234?tab=checks", tableImportID{TableID: 1234}},
+		{"https://anomalo.example.com/dashboard/tables/1234/checks/56", tableImportID{TableID: 1234}},
+		{"https://anomalo.example.com/dashboard/table/1234#overview", tableImportID{TableID: 1234}},
+		{"https://anomalo.example.com/dashboard?table_id=1234", tableImportID{TableID: 1234}},
+		{"http://localhost:8000/dashboard?tableId=1234", tableImportID{TableID: 1234}},
+	} {
+		t.Run(tc.id, func(t *testing.T) {
+			importID, err := parseTableImportID(tc.id)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if importID != tc.expected {
+				t.Errorf("expected %+v, got %+v", tc.expected, importID)
+			}
+		})
+	}
+}
+
+func TestParseTableImportIDErrors(t *testing.T) {
+	for _, id := range []string{
+		"",
+		"   ",
+		",,",
+		"0",
+		"-12",
+		"table",
+		"schema.table",
+		"warehouse..table",
+		"warehouse.schema.",
+		"12:",
+		":schema.table",
+		"abc:schema.table",
+		"12:table",
+		"12:warehouse.schema.table",
+		"https://",
+		"https://anomalo.example.com",
+		"https://anomalo.example.com/dashboard/tables",
+		"https://anomalo.example.com/dashboard/tables/",
+		"https://anomalo.example.com/dashboard/tables/abc",
+		"https://anomalo.example.com/dashboard?table_id=abc",
+		"https://anomalo.example.com/%zz",
+	} {
+		t.Run(id, func(t *testing.T) {
+			importID, err := parseTableImportID(id)
+			if err == nil {
+				t.Fatalf("expected an error, got %+v", importID)
+			}
+			if !strings.Contains(err.Error(), tableImportFormats) {
+				t.Errorf("expected the error to list the accepted formats, got %q", err.Error())
+			}
+		})
+	}
+}
+
+func TestParseCheckImportID(t *testing.T) {
+	for _, tc := range []struct {
+		id       string
+		expected checkImportID
+	}{
+		{"1234,56", checkImportID{TableID: 1234, CheckStaticID: 56}},
+		{"1234,-56", checkImportID{TableID: 1234, CheckStaticID: -56}},
+		{"1234,,my_ref", checkImportID{TableID: 1234, Ref: "my_ref"}},
+		{"1234,,my,ref", checkImportID{TableID: 1234, Ref: "my,ref"}},
+		{"warehouse.schema.table/my_ref", checkImportID{TableName: "warehouse.schema.table", Ref: "my_ref"}},
+		{"warehouse.schema.table#56", checkImportID{TableName: "warehouse.schema.table", CheckStaticID: 56}},
+		{"https://anomalo.example.com/dashboard/tables/1234/checks/56", checkImportID{TableID: 1234, URLCheckID: 56}},
+		{"https://anomalo.example.com/dashboard/tables/1234/checks/56/", checkImportID{TableID: 1234, URLCheckID: 56}},
+		{"https://anomalo.example.com/dashboard/tables/1234?check_id=56", checkImportID{TableID: 1234, URLCheckID: 56}},
+		{"https://anomalo.example.com/dashboard/tables/1234?checkStaticId=56",
+			checkImportID{TableID: 1234, CheckStaticID: 56}},
+	} {
+		t.Run(tc.id, func(t *testing.T) {
+			importID, err := parseCheckImportID(tc.id)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if importID != tc.expected {
+				t.Errorf("expected %+v, got %+v", tc.expected, importID)
+			}
+		})
+	}
+}
+
+func TestParseCheckImportIDErrors(t *testing.T) {
+	for _, id := range []string{
+		"",
+		",",
+		",,",
+		",,,",
+		"1234",
+		"1234,",
+		"1234,,",
+		"0,56",
+		"1234,abc",
+		"1234,0",
+		"1234,56,my_ref",
+		"1234,56,,",
+		"abc,56",
+		"warehouse.schema.table",
+		"warehouse.schema.table/",
+		"warehouse.schema.table#",
+		"warehouse.schema.table#abc",
+		"schema.table/my_ref",
+		"12:schema.table/my_ref",
+		"/my_ref",
+		"#56",
+		"https://anomalo.example.com",
+		"https://anomalo.example.com/dashboard/tables/1234",
+		"https://anomalo.example.com/dashboard/tables/1234/checks",
+		"https://anomalo.example.com/dashboard/tables/1234/checks/",
+		"https://anomalo.example.com/dashboard/tables/1234/checks/abc",
+		"https://anomalo.example.com/dashboard/checks/56",
+	} {
+		t.Run(id, func(t *testing.T) {
+			importID, err := parseCheckImportID(id)
+			if err == nil {
+				t.Fatalf("expected an error, got %+v", importID)
+			}
+			if !strings.Contains(err.Error(), checkImportFormats) {
+				t.Errorf("expected the error to list the accepted formats, got %q", err.Error())
+			}
+		})
+	}
+}
@@ -37,7 +37,8 @@ func (r *notificationChannelDataSource) Configure(_ context.Context, req datasou
 		return
 	}
 
-	r.client = req.ProviderData.(*anomalo.Client)
+	data := req.ProviderData.(*providerData)
+	r.client = data.client
 }
 
 // Metadata returns the data source type name.
@@ -0,0 +1,205 @@
+package anomalo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"regexp"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ basetypes.StringTypable                    = paramsJSONType{}
+	_ basetypes.StringValuableWithSemanticEquals = paramsJSONValue{}
+	_ xattr.ValidateableAttribute                = paramsJSONValue{}
+)
+
+// paramsJSONType is a string type for check params encoded as a JSON object. Unlike a map of strings, it preserves
+// the type of each param. Two values are semantically equal if they decode to equivalent params (see
+// normalizeParams), so differences in key order, whitespace, number formatting (ex. 1e6 & 1000000), or SQL
+// formatting don't produce a diff.
+type paramsJSONType struct {
+	basetypes.StringType
+}
+
+func (t paramsJSONType) Equal(o attr.Type) bool {
+	other, ok := o.(paramsJSONType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t paramsJSONType) String() string {
+	return "paramsJSONType"
+}
+
+func (t paramsJSONType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return paramsJSONValue{StringValue: in}, nil
+}
+
+func (t paramsJSONType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+	return paramsJSONValue{StringValue: stringValue}, nil
+}
+
+func (t paramsJSONType) ValueType(_ context.Context) attr.Value {
+	return paramsJSONValue{}
+}
+
+type paramsJSONValue struct {
+	basetypes.StringValue
+}
+
+func newParamsJSONValue(params map[string]interface{}) (paramsJSONValue, error) {
+	encoded, err := json.Marshal(params)
+	if err != nil {
+		return paramsJSONValue{}, err
+	}
+	return paramsJSONValue{StringValue: basetypes.NewStringValue(string(encoded))}, nil
+}
+
+func newParamsJSONNull() paramsJSONValue {
+	return paramsJSONValue{StringValue: basetypes.NewStringNull()}
+}
+
+func (v paramsJSONValue) Type(_ context.Context) attr.Type {
+	return paramsJSONType{}
+}
+
+func (v paramsJSONValue) Equal(o attr.Value) bool {
+	other, ok := o.(paramsJSONValue)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v paramsJSONValue) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	newValue, ok := newValuable.(paramsJSONValue)
+	if !ok {
+		return false, nil
+	}
+	oldParams, err := decodeParamsJSON(v.ValueString())
+	if err != nil {
+		return false, nil
+	}
+	newParams, err := decodeParamsJSON(newValue.ValueString())
+	if err != nil {
+		return false, nil
+	}
+	return reflect.DeepEqual(normalizeParams(oldParams), normalizeParams(newParams)), nil
+}
+
+func (v paramsJSONValue) ValidateAttribute(_ context.Context, req xattr.ValidateAttributeRequest, resp *xattr.ValidateAttributeResponse) {
+	if v.IsNull() || v.IsUnknown() {
+		return
+	}
+	if _, err := decodeParamsJSON(v.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Params JSON",
+			fmt.Sprintf("Expected a JSON object of check params, ex `jsonencode({ ... })`. %s", err.Error()))
+	}
+}
+
+// params decodes the value. It must be known & valid.
+func (v paramsJSONValue) params() (map[string]interface{}, error) {
+	return decodeParamsJSON(v.ValueString())
+}
+
+// decodeParamsJSON decodes a JSON object of params. Numbers decode as json.Number, so they keep their exact value.
+func decodeParamsJSON(value string) (map[string]interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader([]byte(value)))
+	decoder.UseNumber()
+	var params map[string]interface{}
+	if err := decoder.Decode(&params); err != nil {
+		return nil, err
+	}
+	if params == nil {
+		return nil, fmt.Errorf("params must be a JSON object, got null")
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("unexpected data after the params object")
+	}
+	return params, nil
+}
+
+// normalizedNumber is a JSON number written as an exact fraction. It's a distinct type so that numbers never
+// compare equal to strings that aren't numbers.
+type normalizedNumber string
+
+// jsonNumberPattern matches the JSON number grammar.
+var jsonNumberPattern = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
+
+// normalizeJSON rewrites numbers in decoded JSON as exact fractions, so equal numbers compare equal regardless of
+// formatting. Anomalo returns some numbers as strings, so strings holding a JSON number are rewritten too, and compare
+// equal to the number.
+func normalizeJSON(value interface{}) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		return normalizeNumber(v.String())
+	case string:
+		if jsonNumberPattern.MatchString(v) {
+			return normalizeNumber(v)
+		}
+		return v
+	case map[string]interface{}:
+		normalized := make(map[string]interface{}, len(v))
+		for key, val := range v {
+			normalized[key] = normalizeJSON(val)
+		}
+		return normalized
+	case []interface{}:
+		normalized := make([]interface{}, len(v))
+		for i, val := range v {
+			normalized[i] = normalizeJSON(val)
+		}
+		return normalized
+	default:
+		return v
+	}
+}
+
+func normalizeNumber(number string) normalizedNumber {
+	if r, ok := new(big.Rat).SetString(number); ok {
+		return normalizedNumber(r.RatString())
+	}
+	return normalizedNumber(number)
+}
+
+// paramString formats a param value from the API for the `params` map of strings. Strings, numbers & booleans are
+// formatted as their literal value. Lists & objects are formatted as JSON.
+func paramString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprintf("%v", v)
+		}
+		return string(encoded)
+	}
+}
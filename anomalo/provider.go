@@ -7,17 +7,22 @@ import (
 	"os"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/square/anomalo-go/anomalo"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
-var _ provider.Provider = &Provider{}
+var (
+	_ provider.Provider                  = &Provider{}
+	_ provider.ProviderWithListResources = &Provider{}
+)
 
 const (
 	AnomaloHostEnvName     = "ANOMALO_INSTANCE_HOST"
@@ -44,9 +49,23 @@ func (p Provider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *
 }
 
 type ProviderModel struct {
-	Host         types.String `tfsdk:"host"`
-	Token        types.String `tfsdk:"token"`
-	Organization types.String `tfsdk:"organization"`
+	Host                      types.String `tfsdk:"host"`
+	Token                     types.String `tfsdk:"token"`
+	Organization              types.String `tfsdk:"organization"`
+	DefaultDeletionProtection types.Bool   `tfsdk:"default_deletion_protection"`
+	LenientCheckValidation    types.Bool   `tfsdk:"lenient_check_validation"`
+	ProtectCheckHistory       types.Bool   `tfsdk:"protect_check_history"`
+}
+
+// providerData is handed to resources & data sources when they are configured. It carries the API client along with
+// provider-level settings that change resource behavior.
+type providerData struct {
+	client                    *anomalo.Client
+	defaultDeletionProtection bool
+	lenientCheckValidation    bool
+	protectCheckHistory       bool
+	columns                   *tableColumnCache
+	tableProtections          *tableProtections
 }
 
 func (p Provider) Schema(_ context.Context, _ provider.SchemaRequest, resp *provider.SchemaResponse) {
@@ -75,6 +94,26 @@ func (p Provider) Schema(_ context.Context, _ provider.SchemaRequest, resp *prov
 					"Otherwise, the organization of the most recently initialized provider will be used. Configuration" +
 					" order is not guaranteed by the terraform API.",
 			},
+			"default_deletion_protection": schema.BoolAttribute{
+				Optional: true,
+				Description: "The value of `deletion_protection` for tables and checks that don't set it explicitly. " +
+					"Defaults to false. Set to true to protect every resource managed by this provider from " +
+					"accidental destruction.",
+			},
+			"lenient_check_validation": schema.BoolAttribute{
+				Optional: true,
+				Description: "When true, `anomalo_check` check types and params that this version of the provider " +
+					"doesn't know about are warnings rather than errors. Use it for check types or params that " +
+					"Anomalo added recently. Badly typed values of known params are still errors. Defaults to false.",
+			},
+			"protect_check_history": schema.BoolAttribute{
+				Optional: true,
+				Description: "Anomalo applies check updates by replacing the check with a new check ID, so run history " +
+					"and issue links for the old check ID don't carry over. Plans that do so always show a warning. " +
+					"When true, they're errors for checks with `deletion_protection` enabled, and for checks on tables " +
+					"with `deletion_protection` enabled. A table's protection is known when its `anomalo_table` " +
+					"is managed by this provider configuration, and the check references it. Defaults to false.",
+			},
 		},
 	}
 }
@@ -185,14 +224,39 @@ func (p Provider) Configure(ctx context.Context, req provider.ConfigureRequest,
 		}
 	}
 
-	resp.DataSourceData = &client
-	resp.ResourceData = &client
+	// Warehouse dialects are used to compare table names. Without them, names are compared case-insensitively.
+	warehouses, err := listWarehouses(&client)
+	if err != nil {
+		tflog.Warn(ctx, "Unable to list warehouses. Table names will be compared case-insensitively.",
+			map[string]interface{}{"error": err.Error()})
+	} else {
+		registerWarehouseDialects(client.Host, warehouses)
+	}
+
+	data := &providerData{
+		client:                    &client,
+		defaultDeletionProtection: config.DefaultDeletionProtection.ValueBool(),
+		lenientCheckValidation:    config.LenientCheckValidation.ValueBool(),
+		protectCheckHistory:       config.ProtectCheckHistory.ValueBool(),
+		columns:                   newTableColumnCache(&client),
+		tableProtections:          newTableProtections(),
+	}
+	resp.DataSourceData = data
+	resp.ResourceData = data
+	resp.ListResourceData = data
 }
 
 func (p Provider) Resources(_ context.Context) []func() resource.Resource {
-	return []func() resource.Resource{
+	return append([]func() resource.Resource{
 		newTableResource,
 		newCheckResource,
+	}, typedCheckResources()...)
+}
+
+func (p Provider) ListResources(_ context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		newTableListResource,
+		newCheckListResource,
 	}
 }
 
@@ -2,115 +2,228 @@ package anomalo
 
 import (
 	"context"
+	"fmt"
+	"sync"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-func toPtr(str string) *string {
-	if str == "" {
+// privateKeyImporting is set in a resource's private state by ImportState, and cleared by the following Read.
+const privateKeyImporting = "importing"
+
+// stringPtr converts a terraform string to an API value. Null strings are nil, so they're left out of requests.
+func stringPtr(value types.String) *string {
+	if value.IsNull() || value.IsUnknown() {
 		return nil
 	}
+	str := value.ValueString()
 	return &str
 }
 
-// BoolDefaultValue PlanModifier that allows us to set a default value for undefined configuration inputs of types.Bool
-func BoolDefaultValue(v types.Bool) planmodifier.Bool {
-	return &boolDefaultValuePlanModifier{v}
+// boolPtr converts a terraform bool to an API value. Null bools are nil, so they're left out of requests.
+func boolPtr(value types.Bool) *bool {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+	b := value.ValueBool()
+	return &b
 }
 
-type boolDefaultValuePlanModifier struct {
-	DefaultValue types.Bool
+// clearableStringPtr is stringPtr for updates. A string that's null in the plan but set in prior was removed from the
+// configuration, so it's cleared with an empty string rather than left out of the request, which would keep its value.
+func clearableStringPtr(value types.String, prior types.String) *string {
+	if value.IsNull() && !prior.IsNull() {
+		empty := ""
+		return &empty
+	}
+	return stringPtr(value)
 }
 
-var _ planmodifier.Bool = (*boolDefaultValuePlanModifier)(nil)
-
-func (pm boolDefaultValuePlanModifier) Description(_ context.Context) string {
-	return ""
+// clearableBoolPtr is boolPtr for updates. See clearableStringPtr. Removed bools are cleared with false.
+func clearableBoolPtr(value types.Bool, prior types.Bool) *bool {
+	if value.IsNull() && !prior.IsNull() {
+		cleared := false
+		return &cleared
+	}
+	return boolPtr(value)
 }
 
-func (pm boolDefaultValuePlanModifier) MarkdownDescription(_ context.Context) string {
-	return ""
-}
+// Optional attributes that are null in state aren't managed by terraform, and Anomalo applies its own default for
+// them. The fromAPI helpers below keep those attributes null when reading, so the defaults don't show up as a diff.
+// When adoptUnset is true (ex. imports & list results), values Anomalo has are adopted into state instead, so that
+// generated configuration reflects the table. Values that are set in state, including empty strings, always reflect
+// Anomalo so that drift is detected.
 
-func (pm boolDefaultValuePlanModifier) PlanModifyBool(_ context.Context, req planmodifier.BoolRequest, res *planmodifier.BoolResponse) {
-	// Nothing to do if the config value is set
-	if !req.ConfigValue.IsNull() {
-		return
+// stringFromAPI converts an API string to a terraform value. See above.
+func stringFromAPI(prior types.String, value string, adoptUnset bool) types.String {
+	if prior.IsNull() && (!adoptUnset || value == "") {
+		return types.StringNull()
 	}
-
-	res.PlanValue = pm.DefaultValue
-}
-
-// DefaultEmptyList PlanModifier that allows us to set a default value for undefined configuration inputs of types.List
-func DefaultEmptyList() planmodifier.List {
-	return &listDefaultValuePlanModifier{}
+	return types.StringValue(value)
 }
 
-type listDefaultValuePlanModifier struct {
-	DefaultValue types.List
+// boolFromAPI converts an API bool to a terraform value. See above.
+func boolFromAPI(prior types.Bool, value bool, adoptUnset bool) types.Bool {
+	if prior.IsNull() && (!adoptUnset || !value) {
+		return types.BoolNull()
+	}
+	return types.BoolValue(value)
 }
 
-var _ planmodifier.List = (*listDefaultValuePlanModifier)(nil)
-
-func (pm listDefaultValuePlanModifier) Description(_ context.Context) string {
-	return ""
+// stringSetValue converts API strings to a terraform set. Duplicates are dropped.
+func stringSetValue(values []string) (types.Set, diag.Diagnostics) {
+	seen := map[string]bool{}
+	elements := []attr.Value{}
+	for _, val := range values {
+		if !seen[val] {
+			seen[val] = true
+			elements = append(elements, types.StringValue(val))
+		}
+	}
+	return types.SetValue(types.StringType, elements)
 }
 
-func (pm listDefaultValuePlanModifier) MarkdownDescription(_ context.Context) string {
-	return ""
+// deletionProtectionAttribute is the `deletion_protection` schema attribute shared by all resources.
+func deletionProtectionAttribute() schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Optional: true,
+		Computed: true,
+		Description: "When true, Terraform refuses to destroy this resource (including destroy-then-create " +
+			"replacements), and plans that would destroy it show a warning. Set to false and apply before " +
+			"destroying. Defaults to the provider's `default_deletion_protection`.",
+	}
 }
 
-func (pm listDefaultValuePlanModifier) PlanModifyList(_ context.Context, req planmodifier.ListRequest, res *planmodifier.ListResponse) {
-	// Nothing to do if the config value is set
-	if !req.ConfigValue.IsNull() {
+// planDeletionProtection fills in the provider-level default for `deletion_protection` when the configuration
+// leaves it unset, and warns when the plan destroys a protected resource. Must be called from ModifyPlan.
+func planDeletionProtection(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse,
+	defaultValue bool, resourceDescription string) {
+	// Destroy plan
+	if req.Plan.Raw.IsNull() {
+		if isDeletionProtected(ctx, req.State, &resp.Diagnostics) {
+			resp.Diagnostics.AddWarning(
+				"Destroying a Resource with Deletion Protection",
+				fmt.Sprintf("This plan destroys %s, which has `deletion_protection` enabled. The apply will fail "+
+					"for this resource unless `deletion_protection` is first set to false and applied.",
+					resourceDescription),
+			)
+		}
 		return
 	}
-	emptyList, diags := types.ListValue(types.StringType, []attr.Value{})
-	res.Diagnostics.Append(diags...)
-	if res.Diagnostics.HasError() {
+
+	var configured types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("deletion_protection"), &configured)...)
+	if resp.Diagnostics.HasError() || !configured.IsNull() {
 		return
 	}
 
-	res.PlanValue = emptyList
+	planned := types.BoolValue(defaultValue)
+	if !defaultValue && !req.State.Raw.IsNull() {
+		// Imported resources store an unset value as null. Null and false are equivalent, so don't plan a no-op diff.
+		var prior types.Bool
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("deletion_protection"), &prior)...)
+		if prior.IsNull() {
+			planned = prior
+		}
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("deletion_protection"), planned)...)
 }
 
-// EmptyIfNull PlanModifier that converts undefined strings to the empty string ""
-func EmptyIfNull() planmodifier.String {
-	return &nullToEmptyStringPlanModifier{}
+// configuredChangeRequiresReplace reports whether a configured value of one of the attributes differs from state, so
+// that their RequiresReplace or RequiresReplaceIfConfigured plan modifiers replace the resource. ModifyPlan can't use
+// resp.RequiresReplace for this: it only has the paths added by ModifyPlan itself, not by attribute plan modifiers.
+func configuredChangeRequiresReplace(ctx context.Context, req resource.ModifyPlanRequest, diags *diag.Diagnostics,
+	attributes ...path.Path) bool {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return false
+	}
+	for _, attribute := range attributes {
+		var configured, prior attr.Value
+		diags.Append(req.Config.GetAttribute(ctx, attribute, &configured)...)
+		diags.Append(req.State.GetAttribute(ctx, attribute, &prior)...)
+		if configured != nil && !configured.IsNull() && !configured.Equal(prior) {
+			return true
+		}
+	}
+	return false
 }
 
-type nullToEmptyStringPlanModifier struct{}
+// warnIfProtectedReplacement warns when a plan replaces a resource with `deletion_protection` enabled. The destroy
+// half of the replacement will be refused during apply.
+func warnIfProtectedReplacement(ctx context.Context, state tfsdk.State, resourceDescription string, diags *diag.Diagnostics) {
+	if isDeletionProtected(ctx, state, diags) {
+		diags.AddWarning(
+			"Replacing a Resource with Deletion Protection",
+			fmt.Sprintf("This plan replaces %s, which has `deletion_protection` enabled. The apply will fail "+
+				"for this resource unless `deletion_protection` is first set to false and applied.",
+				resourceDescription),
+		)
+	}
+}
 
-var _ planmodifier.String = (*nullToEmptyStringPlanModifier)(nil)
+// isDeletionProtected reports whether the resource in the provided state has `deletion_protection` enabled.
+func isDeletionProtected(ctx context.Context, state tfsdk.State, diags *diag.Diagnostics) bool {
+	if state.Raw.IsNull() {
+		return false
+	}
+	var protected types.Bool
+	diags.Append(state.GetAttribute(ctx, path.Root("deletion_protection"), &protected)...)
+	return protected.ValueBool()
+}
 
-func (pm nullToEmptyStringPlanModifier) Description(_ context.Context) string {
-	return ""
+// tableProtections records the `deletion_protection` of the tables managed by a provider configuration, by table ID.
+// It's only stored in Terraform state, so checks can't read their table's protection otherwise. Tables record it when
+// they're read or planned, which Terraform does before planning checks that reference them.
+type tableProtections struct {
+	mu        sync.RWMutex
+	protected map[int]bool
 }
 
-func (pm nullToEmptyStringPlanModifier) MarkdownDescription(_ context.Context) string {
-	return ""
+func newTableProtections() *tableProtections {
+	return &tableProtections{protected: map[int]bool{}}
 }
 
-// PlanModifyString
-// This method equates null strings to empty strings. So if this modifier is applied to a Definition attribute,
-//   - resource "anomalo_table" "table_name" {}
-//   - resource "anomalo_table" "table_name" {definition: null}
-//   - resource "anomalo_table" "table_name" {definition: ""}
-//
-// result in the same value for Definition.
-//
-// If we discover cases where anomalo differentiate between unset variables and empty strings, we will need to update
-// the library accordingly.
-//
-// It did not seem possible to instead store strings as null, because terraform did not like it when the PlanModifier
-// changed the value from "string" in the config to "null" after the modifier executes.
-func (pm nullToEmptyStringPlanModifier) PlanModifyString(_ context.Context, req planmodifier.StringRequest, res *planmodifier.StringResponse) {
-	// Nothing to do if the config value is set
-	if !req.ConfigValue.IsNull() {
+// record stores the protection of a table. Unknown table IDs are ignored.
+func (p *tableProtections) record(tableID types.Int64, protected types.Bool) {
+	if p == nil || tableID.IsNull() || tableID.IsUnknown() {
 		return
 	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.protected[int(tableID.ValueInt64())] = protected.ValueBool()
+}
 
-	res.PlanValue = types.StringValue("")
+// isProtected reports whether the table has `deletion_protection` enabled. Tables that no `anomalo_table` resource
+// has recorded aren't protected.
+func (p *tableProtections) isProtected(tableID int) bool {
+	if p == nil {
+		return false
+	}
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.protected[tableID]
+}
+
+// deletionProtectedError is returned by Delete methods when a protected resource would be destroyed.
+func deletionProtectedError(resourceDescription string) diag.Diagnostic {
+	return diag.NewErrorDiagnostic(
+		"Resource is Protected from Deletion",
+		fmt.Sprintf("Refusing to destroy %s because `deletion_protection` is enabled. If you really intend to "+
+			"destroy it, set `deletion_protection = false`, run `terraform apply`, and then destroy it.",
+			resourceDescription),
+	)
+}
+
+// setIdentity stores the resource identity, if the Terraform client supports resource identity (Terraform 1.12+).
+func setIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, val interface{}, diags *diag.Diagnostics) {
+	if identity == nil {
+		return
+	}
+	diags.Append(identity.Set(ctx, val)...)
 }
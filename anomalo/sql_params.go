@@ -0,0 +1,129 @@
+package anomalo
+
+import (
+	"strings"
+	"unicode"
+)
+
+// sqlParams are check params known to hold SQL, in addition to any param named `sql` or ending in `_sql`.
+var sqlParams = map[string]bool{
+	"query":          true,
+	"custom_sql":     true,
+	"sql_expression": true,
+	"where_clause":   true,
+	"filter":         true,
+}
+
+func isSQLParam(key string) bool {
+	return key == "sql" || strings.HasSuffix(key, "_sql") || sqlParams[key]
+}
+
+// normalizeSQL returns a form of a SQL string that is identical for cosmetic variations of the same statement.
+// Anomalo trims trailing whitespace & normalizes newlines when it stores SQL, and users reformat SQL without changing
+// it. Outside of quoted strings, identifiers & comments, runs of whitespace (including line endings) become a single
+// space, and leading & trailing whitespace and semicolons are dropped, including semicolons followed only by comments.
+// Quoted text & block comments are kept exactly. Line comments (`-- ...`) are kept without trailing whitespace, and the
+// line ending that closes one is kept, since joining the next line onto the comment would comment it out.
+func normalizeSQL(sql string) string {
+	var normalized strings.Builder
+	var lineComment strings.Builder
+	var closingQuote rune
+	inLineComment, inBlockComment := false, false
+	pendingSpace, lineStart := false, false
+	// The offsets of semicolons in normalized, and the end of the last other character outside of comments, so that
+	// trailing semicolons can be dropped.
+	var semicolons []int
+	codeEnd := 0
+	runes := []rune(sql)
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+		var next rune
+		if i+1 < len(runes) {
+			next = runes[i+1]
+		}
+		switch {
+		case closingQuote != 0:
+			normalized.WriteRune(c)
+			codeEnd = normalized.Len()
+			if c == closingQuote {
+				closingQuote = 0
+			}
+		case inLineComment && c == '\n':
+			normalized.WriteString(strings.TrimRightFunc(lineComment.String(), unicode.IsSpace))
+			normalized.WriteRune('\n')
+			lineComment.Reset()
+			inLineComment, pendingSpace, lineStart = false, false, true
+		case inLineComment:
+			lineComment.WriteRune(c)
+		case inBlockComment:
+			normalized.WriteRune(c)
+			if c == '*' && next == '/' {
+				normalized.WriteRune(next)
+				i++
+				inBlockComment = false
+			}
+		case unicode.IsSpace(c):
+			pendingSpace = true
+		default:
+			if pendingSpace && normalized.Len() > 0 && !lineStart {
+				normalized.WriteRune(' ')
+			}
+			pendingSpace, lineStart = false, false
+			switch {
+			case c == '-' && next == '-':
+				inLineComment = true
+				lineComment.WriteString("--")
+				i++
+				continue
+			case c == '/' && next == '*':
+				inBlockComment = true
+				normalized.WriteString("/*")
+				i++
+				continue
+			}
+			if c == ';' {
+				semicolons = append(semicolons, normalized.Len())
+			}
+			normalized.WriteRune(c)
+			if c != ';' {
+				codeEnd = normalized.Len()
+			}
+			switch c {
+			case '\'', '"', '`':
+				closingQuote = c
+			}
+		}
+	}
+	if inLineComment {
+		normalized.WriteString(strings.TrimRightFunc(lineComment.String(), unicode.IsSpace))
+	}
+
+	result := normalized.String()
+	for i := len(semicolons) - 1; i >= 0 && semicolons[i] >= codeEnd; i-- {
+		start := semicolons[i]
+		if start > 0 && result[start-1] == ' ' {
+			start--
+		}
+		result = result[:start] + result[semicolons[i]+1:]
+	}
+	return strings.TrimRight(result, " \n")
+}
+
+// sqlEqual reports whether two SQL strings differ only cosmetically. See normalizeSQL.
+func sqlEqual(a, b string) bool {
+	return normalizeSQL(a) == normalizeSQL(b)
+}
+
+// normalizeParams returns a form of check params that is identical for equivalent params. SQL params are compared
+// with normalizeSQL, and numbers by value.
+func normalizeParams(params map[string]interface{}) map[string]interface{} {
+	normalized := make(map[string]interface{}, len(params))
+	for key, val := range params {
+		if sql, ok := val.(string); ok && isSQLParam(key) {
+			normalized[key] = normalizeSQL(sql)
+			continue
+		}
+		normalized[key] = normalizeJSON(val)
+	}
+	return normalized
+}
@@ -0,0 +1,54 @@
+package anomalo
+
+import "testing"
+
+func TestNormalizeSQL(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		sql      string
+		expected string
+	}{
+		{"whitespace", "  select\n\ta,   b\r\nfrom t  \n", "select a, b from t"},
+		{"trailing semicolons", "select 1 ;; \n", "select 1"},
+		{"semicolon between statements", "select 1;\nselect 2;", "select 1; select 2"},
+		{"line comment", "select a -- the a column   \nfrom t", "select a -- the a column\nfrom t"},
+		{"line comment at the end", "select 1 -- done  ", "select 1 -- done"},
+		{"semicolon before a line comment", "select 1; -- done", "select 1 -- done"},
+		{"semicolon before a line comment on the next line", "select 1;\n-- done\n", "select 1 -- done"},
+		{"semicolon in a trailing comment", "select 1 -- done;", "select 1 -- done;"},
+		{"semicolon before a block comment", "select 1 ; /* done */", "select 1 /* done */"},
+		{"block comment", "select /*  keep\n  this */ a", "select /*  keep\n  this */ a"},
+		{"string literal", "select 'a  b' from t", "select 'a  b' from t"},
+		{"string literal containing a comment", "where a = '--  x' and  b", "where a = '--  x' and b"},
+		{"string literal containing a semicolon", "select ';'", "select ';'"},
+		{"quoted identifier", `select "my  col" from t`, `select "my  col" from t`},
+		{"backtick identifier", "select `my  col` from t", "select `my  col` from t"},
+		{"array subscript", "select arr[1],  b from t", "select arr[1], b from t"},
+		{"bracket identifier", "select [my col],  b from t", "select [my col], b from t"},
+		{"unbalanced bracket", "select arr[1  from t where  a", "select arr[1 from t where a"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if normalized := normalizeSQL(tc.sql); normalized != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, normalized)
+			}
+		})
+	}
+}
+
+func TestSQLEqual(t *testing.T) {
+	for _, tc := range []struct {
+		a, b  string
+		equal bool
+	}{
+		{"select 1; -- done", "select 1 -- done", true},
+		{"select 1;\n-- done", "select 1 -- done\n", true},
+		{"select a\nfrom t;", "select a from t", true},
+		{"select 'a b'", "select 'a  b'", false},
+		{"select a -- x\nfrom t", "select a from t", false},
+		{"select 1 -- done", "select 1 -- not done", false},
+	} {
+		if equal := sqlEqual(tc.a, tc.b); equal != tc.equal {
+			t.Errorf("expected sqlEqual(%q, %q) to be %t", tc.a, tc.b, tc.equal)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package anomalo
+
+import (
+	"context"
+	"fmt"
+	pathpkg "path"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/square/anomalo-go/anomalo"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ list.ListResource                   = &tableListResource{}
+	_ list.ListResourceWithConfigure      = &tableListResource{}
+	_ list.ListResourceWithValidateConfig = &tableListResource{}
+)
+
+func newTableListResource() list.ListResource {
+	return &tableListResource{}
+}
+
+// tableListResource discovers existing Anomalo tables for `terraform query`, so they can be bulk-imported.
+type tableListResource struct {
+	client *anomalo.Client
+}
+
+// Values expected in the list block configuration
+type tableListResourceModel struct {
+	WarehouseID    types.Int64  `tfsdk:"warehouse_id"`
+	WarehouseName  types.String `tfsdk:"warehouse_name"`
+	SchemaPattern  types.String `tfsdk:"schema_pattern"`
+	ConfiguredOnly types.Bool   `tfsdk:"configured_only"`
+}
+
+func (r *tableListResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data := req.ProviderData.(*providerData)
+	r.client = data.client
+}
+
+// Metadata returns the resource type name. It matches the managed resource that results are imported as.
+func (r *tableListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_table"
+}
+
+// ListResourceConfigSchema defines the schema for the list block configuration.
+func (r *tableListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists tables known to Anomalo, so they can be imported with `terraform query`.",
+		Attributes: map[string]schema.Attribute{
+			"warehouse_id": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Only list tables in the warehouse with this ID.",
+			},
+			"warehouse_name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list tables in the warehouse with this name.",
+			},
+			"schema_pattern": schema.StringAttribute{
+				Optional: true,
+				Description: "Only list tables whose schema name matches this glob pattern. Ex `analytics_*`. " +
+					"Matching is case-sensitive.",
+			},
+			"configured_only": schema.BoolAttribute{
+				Optional: true,
+				Description: "Only list tables that are already configured in Anomalo (have a check cadence). " +
+					"Defaults to false.",
+			},
+		},
+	}
+}
+
+func (r *tableListResource) ValidateListResourceConfig(ctx context.Context, req list.ValidateConfigRequest, resp *list.ValidateConfigResponse) {
+	var config tableListResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.SchemaPattern.IsNull() && !config.SchemaPattern.IsUnknown() {
+		if _, err := pathpkg.Match(config.SchemaPattern.ValueString(), ""); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("schema_pattern"),
+				"Invalid Schema Pattern",
+				fmt.Sprintf("%q is not a valid glob pattern: %s", config.SchemaPattern.ValueString(), err.Error()),
+			)
+		}
+	}
+}
+
+// List streams every table matching the configured filters.
+func (r *tableListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config tableListResourceModel
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	allWarehouses, err := listWarehouses(r.client)
+	if err != nil {
+		stream.Results = list.ListResultsStreamDiagnostics(diag.Diagnostics{diag.NewErrorDiagnostic(
+			"Error Listing Tables",
+			fmt.Sprintf("Could not list warehouses, unexpected error: %s", err.Error()),
+		)})
+		return
+	}
+	var warehouses []warehouse
+	for _, w := range allWarehouses {
+		if !config.WarehouseID.IsNull() && int64(w.ID) != config.WarehouseID.ValueInt64() {
+			continue
+		}
+		if !config.WarehouseName.IsNull() && !tableNamesEqual(w.Name, config.WarehouseName.ValueString()) {
+			continue
+		}
+		warehouses = append(warehouses, w)
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		var count int64
+		for _, w := range warehouses {
+			tables, err := listTables(r.client, w.ID)
+			if err != nil {
+				push(errorListResult("Error Listing Tables",
+					fmt.Sprintf("Could not list tables for warehouse %s, unexpected error: %s", w.Name, err.Error())))
+				return
+			}
+
+			for _, summary := range tables {
+				if !config.SchemaPattern.IsNull() {
+					schemaName := splitTableName(summary.FullName)[0]
+					if matched, _ := pathpkg.Match(config.SchemaPattern.ValueString(), schemaName); !matched {
+						continue
+					}
+				}
+
+				// Only fetch full table information when it's needed.
+				var table *tableInformation
+				if config.ConfiguredOnly.ValueBool() || req.IncludeResource {
+					table, err = getTableInformationByID(r.client, summary.ID)
+					if err != nil {
+						push(errorListResult("Error Listing Tables",
+							fmt.Sprintf("Could not read table ID %d, unexpected error: %s", summary.ID, err.Error())))
+						return
+					}
+					if config.ConfiguredOnly.ValueBool() && table.Config.CheckCadenceType == "" {
+						continue
+					}
+				}
+
+				if !push(r.listResult(ctx, req, w, summary, table)) {
+					return
+				}
+				count++
+				if req.Limit > 0 && count >= req.Limit {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (r *tableListResource) listResult(ctx context.Context, req list.ListRequest, w warehouse, summary tableSummary,
+	table *tableInformation) list.ListResult {
+	result := req.NewListResult(ctx)
+	result.DisplayName = fmt.Sprintf("%s.%s", w.Name, summary.FullName)
+	identity := tableResourceIdentityModel{TableID: types.Int64Value(int64(summary.ID))}
+	result.Diagnostics.Append(result.Identity.Set(ctx, identity)...)
+
+	if req.IncludeResource && table != nil {
+		var model tableResourceModel
+		result.Diagnostics.Append(model.setFromTable(ctx, table, true)...)
+		result.Diagnostics.Append(result.Resource.Set(ctx, model)...)
+	}
+	return result
+}
+
+// errorListResult is a list result that only reports an error.
+func errorListResult(summary string, detail string) list.ListResult {
+	return list.ListResult{Diagnostics: diag.Diagnostics{diag.NewErrorDiagnostic(summary, detail)}}
+}
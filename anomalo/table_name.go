@@ -0,0 +1,231 @@
+package anomalo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ basetypes.StringTypable                    = tableNameType{}
+	_ basetypes.StringValuableWithSemanticEquals = tableNameValue{}
+)
+
+// tableNameType is a string type for fully qualified table names (warehouse.schema.table). Two names are
+// semantically equal if they refer to the same table, so differences in case, identifier quoting, or whitespace
+// between the configuration and Anomalo's canonical name don't produce a diff.
+type tableNameType struct {
+	basetypes.StringType
+}
+
+func (t tableNameType) Equal(o attr.Type) bool {
+	other, ok := o.(tableNameType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t tableNameType) String() string {
+	return "tableNameType"
+}
+
+func (t tableNameType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return tableNameValue{StringValue: in}, nil
+}
+
+func (t tableNameType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+	return tableNameValue{StringValue: stringValue}, nil
+}
+
+func (t tableNameType) ValueType(_ context.Context) attr.Value {
+	return tableNameValue{}
+}
+
+type tableNameValue struct {
+	basetypes.StringValue
+}
+
+func newTableNameValue(name string) tableNameValue {
+	return tableNameValue{StringValue: basetypes.NewStringValue(name)}
+}
+
+func (v tableNameValue) Type(_ context.Context) attr.Type {
+	return tableNameType{}
+}
+
+func (v tableNameValue) Equal(o attr.Value) bool {
+	other, ok := o.(tableNameValue)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v tableNameValue) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	newValue, ok := newValuable.(tableNameValue)
+	if !ok {
+		return false, nil
+	}
+	return tableNamesEqual(v.ValueString(), newValue.ValueString()), nil
+}
+
+// warehouseDialect describes how a warehouse resolves the case of table identifiers.
+type warehouseDialect int
+
+const (
+	// dialectCaseInsensitive covers most warehouses (Snowflake, Postgres, Redshift, Databricks, ...), which fold the
+	// case of identifiers. Anomalo reports names in their canonical case, so any casing refers to the same table.
+	dialectCaseInsensitive warehouseDialect = iota
+	// dialectCaseSensitive covers warehouses (ex. BigQuery) where tables that differ only by case are distinct.
+	dialectCaseSensitive
+)
+
+func dialectForWarehouseType(warehouseType string) warehouseDialect {
+	switch strings.ToLower(warehouseType) {
+	case "bigquery":
+		return dialectCaseSensitive
+	default:
+		return dialectCaseInsensitive
+	}
+}
+
+// warehouseDialects maps each Anomalo host to the dialects of its warehouses, by lower-cased warehouse name. It's
+// populated when the provider is configured. Each provider configuration (ex. aliases for different Anomalo
+// instances) registers its own host, so they don't overwrite each other's warehouses.
+var (
+	warehouseDialectsMu sync.RWMutex
+	warehouseDialects   = map[string]map[string]warehouseDialect{}
+)
+
+func registerWarehouseDialects(host string, warehouses []warehouse) {
+	dialects := make(map[string]warehouseDialect, len(warehouses))
+	for _, w := range warehouses {
+		dialects[strings.ToLower(w.Name)] = dialectForWarehouseType(w.WarehouseType)
+	}
+	warehouseDialectsMu.Lock()
+	defer warehouseDialectsMu.Unlock()
+	warehouseDialects[host] = dialects
+}
+
+// dialectForWarehouse returns the dialect of the named warehouse. Semantic equality can't tell which provider
+// configuration a value belongs to, so if hosts disagree about a warehouse with this name, the case-sensitive dialect
+// wins. That may show a spurious diff, but never hides a change to a different table. Warehouses no host knows about
+// are treated as dialectCaseInsensitive.
+func dialectForWarehouse(warehouseName string) warehouseDialect {
+	warehouseDialectsMu.RLock()
+	defer warehouseDialectsMu.RUnlock()
+	dialect := dialectCaseInsensitive
+	for _, dialects := range warehouseDialects {
+		if d, ok := dialects[strings.ToLower(warehouseName)]; ok && d == dialectCaseSensitive {
+			dialect = dialectCaseSensitive
+		}
+	}
+	return dialect
+}
+
+// tableIdentifier is one part of a table name.
+type tableIdentifier struct {
+	name string
+	// quoted is true if the identifier was quoted. Quoted identifiers are case-sensitive in every dialect.
+	quoted bool
+}
+
+// splitTableIdentifiers splits a table name into its identifiers. Dots inside quoted identifiers ("...", `...`, or
+// [...]) don't split, quotes are stripped, and whitespace around each identifier is trimmed. A doubled double quote or
+// backtick inside a quoted identifier is a literal one.
+func splitTableIdentifiers(name string) []tableIdentifier {
+	var parts []tableIdentifier
+	var current strings.Builder
+	var closingQuote rune
+	quoted := false
+	runes := []rune(name)
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+		switch {
+		case closingQuote != 0 && c == closingQuote && closingQuote != ']' && i+1 < len(runes) && runes[i+1] == c:
+			current.WriteRune(c)
+			i++
+		case closingQuote != 0 && c == closingQuote:
+			closingQuote = 0
+		case closingQuote != 0:
+			current.WriteRune(c)
+		case c == '"' || c == '`':
+			closingQuote = c
+			quoted = true
+		case c == '[':
+			closingQuote = ']'
+			quoted = true
+		case c == '.':
+			parts = append(parts, tableIdentifier{name: strings.TrimSpace(current.String()), quoted: quoted})
+			current.Reset()
+			quoted = false
+		default:
+			current.WriteRune(c)
+		}
+	}
+	return append(parts, tableIdentifier{name: strings.TrimSpace(current.String()), quoted: quoted})
+}
+
+// splitTableName is splitTableIdentifiers, without whether each identifier was quoted.
+func splitTableName(name string) []string {
+	identifiers := splitTableIdentifiers(name)
+	parts := make([]string, 0, len(identifiers))
+	for _, identifier := range identifiers {
+		parts = append(parts, identifier.name)
+	}
+	return parts
+}
+
+// canonicalTableName strips redundant identifier quoting & whitespace from a table name while preserving case. The
+// result is suitable for looking the table up in Anomalo. Identifiers that can't be written unquoted, because they
+// contain a dot or a quote, stay quoted, so `"my.schema".table` doesn't become `my.schema.table`.
+func canonicalTableName(name string) string {
+	identifiers := splitTableIdentifiers(name)
+	parts := make([]string, 0, len(identifiers))
+	for _, identifier := range identifiers {
+		if identifier.quoted && strings.ContainsAny(identifier.name, ".\"`[]") {
+			parts = append(parts, `"`+strings.ReplaceAll(identifier.name, `"`, `""`)+`"`)
+			continue
+		}
+		parts = append(parts, identifier.name)
+	}
+	return strings.Join(parts, ".")
+}
+
+// tableNamesEqual reports whether two table names refer to the same table. Warehouse names are Anomalo identifiers,
+// and are never case-sensitive. Other identifiers are compared case-insensitively only if the warehouse folds the
+// case of identifiers and neither is quoted. Anomalo reports names unquoted, in their actual case, so a quoted
+// identifier matches Anomalo's name for the table exactly.
+func tableNamesEqual(a, b string) bool {
+	aParts, bParts := splitTableIdentifiers(a), splitTableIdentifiers(b)
+	if len(aParts) != len(bParts) || !strings.EqualFold(aParts[0].name, bParts[0].name) {
+		return false
+	}
+	caseSensitive := dialectForWarehouse(aParts[0].name) == dialectCaseSensitive
+	for i := 1; i < len(aParts); i++ {
+		if caseSensitive || aParts[i].quoted || bParts[i].quoted {
+			if aParts[i].name != bParts[i].name {
+				return false
+			}
+		} else if !strings.EqualFold(aParts[i].name, bParts[i].name) {
+			return false
+		}
+	}
+	return true
+}
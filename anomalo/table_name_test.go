@@ -0,0 +1,77 @@
+package anomalo
+
+import "testing"
+
+func TestCanonicalTableName(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		expected string
+	}{
+		{"warehouse.schema.table", "warehouse.schema.table"},
+		{" Warehouse . Schema . Table ", "Warehouse.Schema.Table"},
+		{`warehouse."Schema".[Table]`, "warehouse.Schema.Table"},
+		{"warehouse.`schema`.table", "warehouse.schema.table"},
+		{`warehouse."my.schema".table`, `warehouse."my.schema".table`},
+		{"warehouse.`my.schema`.table", `warehouse."my.schema".table`},
+		{"warehouse.[my.schema].table", `warehouse."my.schema".table`},
+		{`warehouse."my""schema".table`, `warehouse."my""schema".table`},
+		{"warehouse.`my``schema`.table", "warehouse.\"my`schema\".table"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if canonical := canonicalTableName(tc.name); canonical != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, canonical)
+			}
+			if canonical := canonicalTableName(canonicalTableName(tc.name)); canonical != tc.expected {
+				t.Errorf("expected the canonical name to be stable, got %q", canonical)
+			}
+		})
+	}
+
+	if canonicalTableName(`warehouse."my.schema".table`) == canonicalTableName("warehouse.my.schema.table") {
+		t.Errorf("expected a quoted identifier containing a dot to differ from separate identifiers")
+	}
+}
+
+func TestTableNamesEqual(t *testing.T) {
+	host := "https://table-names.example.com"
+	registerWarehouseDialects(host, []warehouse{
+		{Name: "snowflake_wh", WarehouseType: "snowflake"},
+		{Name: "bigquery_wh", WarehouseType: "bigquery"},
+	})
+	t.Cleanup(func() {
+		warehouseDialectsMu.Lock()
+		defer warehouseDialectsMu.Unlock()
+		delete(warehouseDialects, host)
+	})
+
+	for _, tc := range []struct {
+		a, b  string
+		equal bool
+	}{
+		// Case-insensitive warehouses, and warehouses no host knows about.
+		{"snowflake_wh.schema.table", "SNOWFLAKE_WH.SCHEMA.TABLE", true},
+		{"snowflake_wh.schema.table", `snowflake_wh."schema".table`, true},
+		{"snowflake_wh.SCHEMA.table", `snowflake_wh."schema".table`, false},
+		{"snowflake_wh.schema.table", "snowflake_wh.schema.other", false},
+		{"unknown_wh.Schema.Table", "unknown_wh.schema.table", true},
+		// Case-sensitive warehouses. Warehouse names are Anomalo's, and never case-sensitive.
+		{"bigquery_wh.dataset.table", "BIGQUERY_WH.dataset.table", true},
+		{"bigquery_wh.dataset.table", "bigquery_wh.Dataset.table", false},
+		{"bigquery_wh.dataset.table", "bigquery_wh.`dataset`.table", true},
+		// Quoted identifiers containing dots.
+		{`snowflake_wh."my.schema".table`, "snowflake_wh.my.schema.table", false},
+		{`snowflake_wh."my.schema".table`, "snowflake_wh.[my.schema].table", true},
+		{`snowflake_wh."my.schema".table`, `snowflake_wh."MY.SCHEMA".table`, false},
+		{"bigquery_wh.`my.dataset`.table", `bigquery_wh."my.dataset".table`, true},
+		{"snowflake_wh.schema.table", "snowflake_wh.schema", false},
+	} {
+		t.Run(tc.a+" "+tc.b, func(t *testing.T) {
+			if equal := tableNamesEqual(tc.a, tc.b); equal != tc.equal {
+				t.Errorf("expected tableNamesEqual(%q, %q) to be %t", tc.a, tc.b, tc.equal)
+			}
+			if equal := tableNamesEqual(tc.b, tc.a); equal != tc.equal {
+				t.Errorf("expected tableNamesEqual(%q, %q) to be %t", tc.b, tc.a, tc.equal)
+			}
+		})
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"sort"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -14,19 +16,24 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/square/anomalo-go/anomalo"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &tableResource{}
-	_ resource.ResourceWithConfigure   = &tableResource{}
-	_ resource.ResourceWithImportState = &tableResource{}
+	_ resource.Resource                 = &tableResource{}
+	_ resource.ResourceWithConfigure    = &tableResource{}
+	_ resource.ResourceWithImportState  = &tableResource{}
+	_ resource.ResourceWithModifyPlan   = &tableResource{}
+	_ resource.ResourceWithIdentity     = &tableResource{}
+	_ resource.ResourceWithUpgradeState = &tableResource{}
 )
 
 func newTableResource() resource.Resource {
@@ -34,23 +41,80 @@ func newTableResource() resource.Resource {
 }
 
 type tableResource struct {
-	client *anomalo.Client
+	client                    *anomalo.Client
+	defaultDeletionProtection bool
+	columns                   *tableColumnCache
+	tableProtections          *tableProtections
 }
 
 // Values expected in the state & configuration
 type tableResourceModel struct {
-	TableName                 types.String `tfsdk:"table_name"`
-	TableID                   types.Int64  `tfsdk:"table_id"`
-	CheckCadenceType          types.String `tfsdk:"check_cadence_type"`
-	CheckCadenceRunAtDuration types.String `tfsdk:"check_cadence_run_at_duration"`
-	NotificationChannelID     types.Int64  `tfsdk:"notification_channel_id"`
-	Definition                types.String `tfsdk:"definition"`
-	TimeColumnType            types.String `tfsdk:"time_column_type"`
-	NotifyAfter               types.String `tfsdk:"notify_after"`
-	FreshAfter                types.String `tfsdk:"fresh_after"`
-	IntervalSkipExpr          types.String `tfsdk:"interval_skip_expr"`
-	AlwaysAlertOnErrors       types.Bool   `tfsdk:"always_alert_on_errors"`
-	TimeColumns               types.List   `tfsdk:"time_columns"`
+	TableName                 tableNameValue `tfsdk:"table_name"`
+	TableID                   types.Int64    `tfsdk:"table_id"`
+	CheckCadenceType          types.String   `tfsdk:"check_cadence_type"`
+	CheckCadenceRunAtDuration types.String   `tfsdk:"check_cadence_run_at_duration"`
+	NotificationChannelID     types.Int64    `tfsdk:"notification_channel_id"`
+	Definition                types.String   `tfsdk:"definition"`
+	TimeColumnType            types.String   `tfsdk:"time_column_type"`
+	NotifyAfter               types.String   `tfsdk:"notify_after"`
+	FreshAfter                types.String   `tfsdk:"fresh_after"`
+	IntervalSkipExpr          types.String   `tfsdk:"interval_skip_expr"`
+	AlwaysAlertOnErrors       types.Bool     `tfsdk:"always_alert_on_errors"`
+	TimeColumns               types.Set      `tfsdk:"time_columns"`
+	DeletionProtection        types.Bool     `tfsdk:"deletion_protection"`
+	AdoptExisting             types.Bool     `tfsdk:"adopt_existing"`
+}
+
+// Values of each time_columns element
+type timeColumnModel struct {
+	Name     types.String `tfsdk:"name"`
+	Format   types.String `tfsdk:"format"`
+	Timezone types.String `tfsdk:"timezone"`
+	Type     types.String `tfsdk:"type"`
+}
+
+var timeColumnObjectType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"name":     types.StringType,
+	"format":   types.StringType,
+	"timezone": types.StringType,
+	"type":     types.StringType,
+}}
+
+func (m timeColumnModel) apiValue() timeColumn {
+	return timeColumn{
+		Name:     m.Name.ValueString(),
+		Format:   m.Format.ValueString(),
+		Timezone: m.Timezone.ValueString(),
+		Type:     m.Type.ValueString(),
+	}
+}
+
+// timeColumnsSetValue converts API time columns to a terraform set. Settings Anomalo doesn't report are null.
+func timeColumnsSetValue(ctx context.Context, columns []timeColumn) (types.Set, diag.Diagnostics) {
+	seen := map[timeColumn]bool{}
+	models := []timeColumnModel{}
+	for _, column := range columns {
+		if seen[column] {
+			continue
+		}
+		seen[column] = true
+		models = append(models, timeColumnModel{
+			Name:     types.StringValue(column.Name),
+			Format:   stringFromAPI(types.StringNull(), column.Format, true),
+			Timezone: stringFromAPI(types.StringNull(), column.Timezone, true),
+			Type:     stringFromAPI(types.StringNull(), column.Type, true),
+		})
+	}
+	return types.SetValueFrom(ctx, timeColumnObjectType, models)
+}
+
+// Values in the resource identity. Tables are identified by their Anomalo table ID.
+type tableResourceIdentityModel struct {
+	TableID types.Int64 `tfsdk:"table_id"`
+}
+
+func (m tableResourceModel) identity() tableResourceIdentityModel {
+	return tableResourceIdentityModel{TableID: m.TableID}
 }
 
 func (r *tableResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
@@ -58,7 +122,11 @@ func (r *tableResource) Configure(_ context.Context, req resource.ConfigureReque
 		return
 	}
 
-	r.client = req.ProviderData.(*anomalo.Client)
+	data := req.ProviderData.(*providerData)
+	r.client = data.client
+	r.defaultDeletionProtection = data.defaultDeletionProtection
+	r.columns = data.columns
+	r.tableProtections = data.tableProtections
 }
 
 // Metadata returns the resource type name.
@@ -70,7 +138,10 @@ func (r *tableResource) Metadata(_ context.Context, req resource.MetadataRequest
 func (r *tableResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "An Anomalo table's configuration. Maps closely to the Anomalo API for `configure_table`. See " +
-			"your API documentation for more information on attributes.\n",
+			"your API documentation for more information on attributes. Optional attributes that are omitted are " +
+			"left out of the request, so Anomalo applies its own defaults, and stay null in state. Removing an " +
+			"attribute from the configuration clears it in Anomalo. Empty strings are sent to Anomalo as empty strings.\n",
+		Version: 3,
 		Attributes: map[string]schema.Attribute{
 			"table_id": schema.Int64Attribute{
 				Computed: true,
@@ -83,16 +154,18 @@ func (r *tableResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 					"forgiving imports.",
 			},
 			"table_name": schema.StringAttribute{
-				Required: true,
+				CustomType: tableNameType{},
+				Required:   true,
 				Description: "The fully qualified name of the table, including the warehouse. " +
-					"Ex warehouse_name.schema_name.table_name",
+					"Ex warehouse_name.schema_name.table_name. Changing it to a name that refers to a different " +
+					"Anomalo table replaces the resource: the old table is un-configured and the new one is configured. " +
+					"Changes that refer to the same table (ex. a renamed warehouse) are applied in place, and names " +
+					"Anomalo can't find fail the plan. Differences " +
+					"in case, identifier quoting, or whitespace from Anomalo's canonical name are ignored, except for " +
+					"the case of quoted identifiers, and of any identifier in case-sensitive warehouses like BigQuery.",
 			},
 			"check_cadence_type": schema.StringAttribute{
 				Optional: true,
-				Computed: true,
-				PlanModifiers: []planmodifier.String{
-					EmptyIfNull(),
-				},
 				Validators: []validator.String{
 					// These are example validators from terraform-plugin-framework-validators
 					stringvalidator.RegexMatches(
@@ -106,10 +179,6 @@ func (r *tableResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 			},
 			"check_cadence_run_at_duration": schema.StringAttribute{
 				Optional: true,
-				Computed: true,
-				PlanModifiers: []planmodifier.String{
-					EmptyIfNull(),
-				},
 			},
 			"notification_channel_id": schema.Int64Attribute{
 				Required: true,
@@ -119,58 +188,245 @@ func (r *tableResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 			},
 			"definition": schema.StringAttribute{
 				Optional: true,
-				Computed: true,
-				PlanModifiers: []planmodifier.String{
-					EmptyIfNull(),
-				},
 			},
 			"time_column_type": schema.StringAttribute{
 				Optional: true,
-				Computed: true,
-				PlanModifiers: []planmodifier.String{
-					EmptyIfNull(),
-				},
 			},
 			"notify_after": schema.StringAttribute{
 				Optional: true,
-				Computed: true,
-				PlanModifiers: []planmodifier.String{
-					EmptyIfNull(),
-				},
 			},
 			"fresh_after": schema.StringAttribute{
 				Optional: true,
-				Computed: true,
-				PlanModifiers: []planmodifier.String{
-					EmptyIfNull(),
-				},
 			},
 			"interval_skip_expr": schema.StringAttribute{
 				Optional: true,
-				Computed: true,
-				PlanModifiers: []planmodifier.String{
-					EmptyIfNull(),
-				},
 			},
 			"always_alert_on_errors": schema.BoolAttribute{
 				Optional: true,
-				Computed: true,
-				PlanModifiers: []planmodifier.Bool{
-					BoolDefaultValue(types.BoolValue(false)),
-				},
 			},
-			"time_columns": schema.ListAttribute{
-				Optional:    true,
-				Computed:    true,
-				ElementType: types.StringType,
-				PlanModifiers: []planmodifier.List{
-					DefaultEmptyList(),
+			"time_columns": schema.SetNestedAttribute{
+				Optional: true,
+				Description: "The table's time columns. Anomalo doesn't depend on their order, so neither does this " +
+					"attribute. Column names are validated against the table's columns during plan, when Anomalo " +
+					"can list them.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Required:    true,
+							Description: "The name of the column.",
+						},
+						"format": schema.StringAttribute{
+							Optional:    true,
+							Validators:  []validator.String{stringvalidator.LengthAtLeast(1)},
+							Description: "The format of the column's values, for times stored as strings. Ex `%Y-%m-%d`.",
+						},
+						"timezone": schema.StringAttribute{
+							Optional:    true,
+							Validators:  []validator.String{stringvalidator.LengthAtLeast(1)},
+							Description: "The timezone of the column's values, if they don't include one. Ex `UTC`.",
+						},
+						"type": schema.StringAttribute{
+							Optional:    true,
+							Validators:  []validator.String{stringvalidator.LengthAtLeast(1)},
+							Description: "The type of the column's values. Overrides `time_column_type` for this column.",
+						},
+					},
 				},
 			},
+			"deletion_protection": deletionProtectionAttribute(),
+			"adopt_existing": schema.BoolAttribute{
+				Optional: true,
+				Description: "Only used when the table is created. If the table is already configured in Anomalo (ex. " +
+					"in the UI), this resource takes it over and overwrites the configured attributes. Attributes " +
+					"that aren't configured keep their values in Anomalo. By default, the plan fails instead, with " +
+					"the command to import the table.",
+			},
 		},
 	}
 }
 
+// IdentitySchema defines the identity schema for the resource.
+func (r *tableResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"table_id": identityschema.Int64Attribute{
+				RequiredForImport: true,
+				Description:       "The Anomalo ID of the table.",
+			},
+		},
+	}
+}
+
+// ModifyPlan applies resource-level plan logic that can't be expressed with attribute plan modifiers.
+func (r *tableResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var state tableResourceModel
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		// Checks are planned after the tables they reference. The protection in state applies until it's applied.
+		r.tableProtections.record(state.TableID, state.DeletionProtection)
+	}
+	description := fmt.Sprintf("table %s", state.TableName.ValueString())
+	planDeletionProtection(ctx, req, resp, r.defaultDeletionProtection, description)
+	if resp.Diagnostics.HasError() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan tableResourceModel
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.validateTimeColumns(ctx, state, plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if req.State.Raw.IsNull() {
+		r.validateNotConfigured(plan, nil, &resp.Diagnostics)
+		return
+	}
+	if configuredChangeRequiresReplace(ctx, req, &resp.Diagnostics, path.Root("table_id")) {
+		warnIfProtectedReplacement(ctx, req.State, description, &resp.Diagnostics)
+		return
+	}
+
+	if plan.TableName.Equal(state.TableName) {
+		return
+	}
+
+	// A new table_name may refer to the same Anomalo table (ex. the warehouse was renamed) or to an entirely
+	// different one. The former is updated in place. The latter replaces the resource, so the old table is
+	// un-configured rather than silently reconfigured under the new name.
+	replace, table, diags := r.tableNameChangeRequiresReplace(state, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || !replace {
+		return
+	}
+	// Check now rather than in Create, which runs after Delete has un-configured the old table.
+	r.validateNotConfigured(plan, table, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.RequiresReplace = append(resp.RequiresReplace, path.Root("table_name"))
+	var configuredTableID types.Int64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("table_id"), &configuredTableID)...)
+	if configuredTableID.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("table_id"), types.Int64Unknown())...)
+	}
+	warnIfProtectedReplacement(ctx, req.State, description, &resp.Diagnostics)
+}
+
+// validateTimeColumns checks that the planned time columns exist in the table, so typos are caught before Anomalo
+// runs checks.
+func (r *tableResource) validateTimeColumns(ctx context.Context, state tableResourceModel, plan tableResourceModel,
+	diags *diag.Diagnostics) {
+	if r.client == nil || plan.TimeColumns.IsNull() || plan.TimeColumns.IsUnknown() || plan.TableName.IsUnknown() {
+		return
+	}
+
+	var models []timeColumnModel
+	diags.Append(plan.TimeColumns.ElementsAs(ctx, &models, false)...)
+	var names []string
+	for _, model := range models {
+		if !model.Name.IsUnknown() {
+			names = append(names, model.Name.ValueString())
+		}
+	}
+	if len(names) == 0 {
+		return
+	}
+
+	tableID := int(state.TableID.ValueInt64())
+	if tableID == 0 || !plan.TableName.Equal(state.TableName) {
+		table, err := getTableInformation(r.client, canonicalTableName(plan.TableName.ValueString()))
+		if err != nil || table == nil {
+			// Create & Update report tables that can't be found.
+			return
+		}
+		tableID = table.ID
+	}
+
+	r.columns.validateColumns(ctx, tableID, fmt.Sprintf("table %s", plan.TableName.ValueString()),
+		path.Root("time_columns"), names, diags)
+}
+
+// tableNameChangeRequiresReplace reports whether the planned table_name refers to a different Anomalo table than
+// the one in state, and returns the planned table if it's known. Names that are unknown until apply are treated as
+// different tables. Known names that Anomalo can't resolve are errors rather than replacements: Create would fail on
+// them after Delete un-configured the old table.
+func (r *tableResource) tableNameChangeRequiresReplace(state tableResourceModel, plan tableResourceModel) (bool,
+	*tableInformation, diag.Diagnostics) {
+	if r.client == nil || plan.TableName.IsUnknown() {
+		return true, nil, nil
+	}
+
+	stateTableID, diags := r.tableIdForState(state)
+	if diags.HasError() {
+		return true, nil, diags
+	}
+
+	table, err := getTableInformation(r.client, canonicalTableName(plan.TableName.ValueString()))
+	if isNotFoundError(err) || (err == nil && (table == nil || table.ID == 0)) {
+		diags.AddAttributeError(
+			path.Root("table_name"),
+			"Table Not Found",
+			fmt.Sprintf("Anomalo has no table named %s, so table %s (ID %d) can't be replaced with it. If the "+
+				"table or its warehouse was renamed, set table_name to the name Anomalo now uses for table ID %d. "+
+				"Tables must already exist in Anomalo before they're configured.",
+				plan.TableName.ValueString(), state.TableName.ValueString(), stateTableID, stateTableID),
+		)
+		return false, nil, diags
+	}
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("table_name"),
+			"Error Reading Table",
+			fmt.Sprintf("Could not look up table %s to compare it with table %s (ID %d), unexpected error: %s",
+				plan.TableName.ValueString(), state.TableName.ValueString(), stateTableID, err.Error()),
+		)
+		return false, nil, diags
+	}
+
+	return table.ID != stateTableID, table, nil
+}
+
+// validateNotConfigured adds an error if the planned table is already configured in Anomalo, ex. by a teammate in the
+// UI, and `adopt_existing` isn't set. table is the planned table, or nil to look it up. Create checks again at apply
+// time.
+func (r *tableResource) validateNotConfigured(plan tableResourceModel, table *tableInformation, diags *diag.Diagnostics) {
+	if plan.AdoptExisting.ValueBool() || plan.AdoptExisting.IsUnknown() {
+		return
+	}
+	if table == nil {
+		if r.client == nil || plan.TableName.IsUnknown() {
+			return
+		}
+		var err error
+		table, err = getTableInformation(r.client, canonicalTableName(plan.TableName.ValueString()))
+		if err != nil || table == nil {
+			// Create reports tables that can't be found.
+			return
+		}
+	}
+	if table.Config.CheckCadenceType != "" {
+		diags.Append(tableAlreadyConfiguredError(plan, table))
+	}
+}
+
+// tableAlreadyConfiguredError is the error for configuring a table that's already configured in Anomalo.
+func tableAlreadyConfiguredError(plan tableResourceModel, table *tableInformation) diag.Diagnostic {
+	return diag.NewAttributeErrorDiagnostic(
+		path.Root("table_name"),
+		"Table Already Configured",
+		fmt.Sprintf("Table %s (table ID %d) is already configured in Anomalo, with check cadence %q. To manage it "+
+			"with this resource, import it with `terraform import <terraform-resource-identifier> %d`, or set "+
+			"`adopt_existing = true` to overwrite its configuration. If another resource in this configuration "+
+			"manages it and is being removed, use a `moved` block instead.", plan.TableName.String(), table.ID,
+			table.Config.CheckCadenceType, table.ID),
+	)
+}
+
 // Create creates the resource and sets the initial Terraform state. Note this method doesn't actually "create tables.
 // Anomalo already has an ID for every table it knows about. This method "configures" a table.
 func (r *tableResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -183,8 +439,8 @@ func (r *tableResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 
 	// Confirm Anomalo knows about the table
-	tableName := plan.TableName.ValueString()
-	table, err := r.client.GetTableInformation(tableName)
+	tableName := canonicalTableName(plan.TableName.ValueString())
+	table, err := getTableInformation(r.client, tableName)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Creating Table",
@@ -195,33 +451,37 @@ func (r *tableResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 	tableID := table.ID
 
+	// Don't overwrite a configuration made outside of Terraform, ex. by a teammate in the UI, unless asked to.
+	// ModifyPlan checks this too. This catches tables configured since the plan.
+	if table.Config.CheckCadenceType != "" {
+		if !plan.AdoptExisting.ValueBool() {
+			resp.Diagnostics.Append(tableAlreadyConfiguredError(plan, table))
+			return
+		}
+		tflog.Info(ctx, "Adopting a table that's already configured.", map[string]interface{}{
+			"table_id":           tableID,
+			"table_name":         tableName,
+			"check_cadence_type": table.Config.CheckCadenceType,
+		})
+	} else if settings := table.configuredSettings(); len(settings) > 0 {
+		// Destroying the resource only clears the check cadence, so these are usually left from a prior resource.
+		resp.Diagnostics.AddWarning(
+			"Table Has Prior Configuration",
+			fmt.Sprintf("Table %s isn't monitored, but has a prior configuration of %s. Configured attributes "+
+				"overwrite it. Others keep their values in Anomalo, and aren't managed by Terraform.",
+				plan.TableName.String(), strings.Join(settings, ", ")),
+		)
+	}
+
 	// Populate API request body based on plan values
-	configureTableReq := anomalo.ConfigureTableRequest{
-		TableID:                   tableID,
-		CheckCadenceType:          toPtr(plan.CheckCadenceType.ValueString()),
-		CheckCadenceRunAtDuration: plan.CheckCadenceRunAtDuration.ValueString(),
-		NotificationChannelID:     int(plan.NotificationChannelID.ValueInt64()),
-		Definition:                plan.Definition.ValueString(),
-		AlwaysAlertOnErrors:       plan.AlwaysAlertOnErrors.ValueBool(),
-		TimeColumnType:            plan.TimeColumnType.ValueString(),
-		NotifyAfter:               plan.NotifyAfter.ValueString(),
-		FreshAfter:                plan.FreshAfter.ValueString(),
-		IntervalSkipExpr:          plan.IntervalSkipExpr.ValueString(),
-	}
-
-	// Extract the list values from the plan and add to the request
-	var target []string
-	diags = plan.TimeColumns.ElementsAs(ctx, &target, true)
+	configureTableReq, diags := plan.configureTableRequest(ctx, tableID, nil)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	if len(target) > 0 {
-		configureTableReq.TimeColumns = target
-	}
 
 	// Create new table configuration
-	configureTableResponse, err := r.client.ConfigureTable(configureTableReq)
+	configureTableResponse, err := configureTable(r.client, configureTableReq)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Creating Table",
@@ -235,13 +495,111 @@ func (r *tableResource) Create(ctx context.Context, req resource.CreateRequest,
 	// do not need to be set again.
 	plan.TableID = types.Int64Value(int64(configureTableResponse.ID))
 	plan.NotificationChannelID = types.Int64Value(int64(configureTableReq.NotificationChannelID))
-	plan.AlwaysAlertOnErrors = types.BoolValue(configureTableReq.AlwaysAlertOnErrors)
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	setIdentity(ctx, resp.Identity, plan.identity(), &resp.Diagnostics)
+}
+
+// configuredSettings returns the attributes the table has a non-default value for, other than check_cadence_type and
+// notification_channel_id, which every configuration sets.
+func (t tableInformation) configuredSettings() []string {
+	var settings []string
+	config := t.Config
+	for _, setting := range []struct {
+		attribute string
+		set       bool
+	}{
+		{"check_cadence_run_at_duration", config.CheckCadenceRunAtDuration != ""},
+		{"definition", config.Definition != ""},
+		{"time_column_type", config.TimeColumnType != ""},
+		{"notify_after", config.NotifyAfter != ""},
+		{"fresh_after", config.FreshAfter != ""},
+		{"interval_skip_expr", config.IntervalSkipExpr != ""},
+		{"always_alert_on_errors", config.AlwaysAlertOnErrors},
+		{"time_columns", len(config.TimeColumns) > 0},
+	} {
+		if setting.set {
+			settings = append(settings, "`"+setting.attribute+"`")
+		}
+	}
+	return settings
+}
+
+// configureTableRequest builds the API request for the planned configuration. Null attributes are left out of the
+// request, unless they're set in prior (the state being updated, or nil on create). Anomalo would keep those values,
+// so they're cleared instead.
+func (m tableResourceModel) configureTableRequest(ctx context.Context, tableID int, prior *tableResourceModel) (configureTableRequest, diag.Diagnostics) {
+	if prior == nil {
+		prior = &tableResourceModel{
+			CheckCadenceRunAtDuration: types.StringNull(),
+			Definition:                types.StringNull(),
+			TimeColumnType:            types.StringNull(),
+			NotifyAfter:               types.StringNull(),
+			FreshAfter:                types.StringNull(),
+			IntervalSkipExpr:          types.StringNull(),
+			AlwaysAlertOnErrors:       types.BoolNull(),
+			TimeColumns:               types.SetNull(timeColumnObjectType),
+		}
+	}
+	req := configureTableRequest{
+		TableID:                   tableID,
+		CheckCadenceType:          stringPtr(m.CheckCadenceType),
+		CheckCadenceRunAtDuration: clearableStringPtr(m.CheckCadenceRunAtDuration, prior.CheckCadenceRunAtDuration),
+		NotificationChannelID:     int(m.NotificationChannelID.ValueInt64()),
+		Definition:                clearableStringPtr(m.Definition, prior.Definition),
+		TimeColumnType:            clearableStringPtr(m.TimeColumnType, prior.TimeColumnType),
+		NotifyAfter:               clearableStringPtr(m.NotifyAfter, prior.NotifyAfter),
+		FreshAfter:                clearableStringPtr(m.FreshAfter, prior.FreshAfter),
+		IntervalSkipExpr:          clearableStringPtr(m.IntervalSkipExpr, prior.IntervalSkipExpr),
+		AlwaysAlertOnErrors:       clearableBoolPtr(m.AlwaysAlertOnErrors, prior.AlwaysAlertOnErrors),
+	}
+
+	if m.TimeColumns.IsNull() {
+		if !prior.TimeColumns.IsNull() {
+			req.TimeColumns = &[]timeColumn{}
+		}
+		return req, nil
+	}
+	var models []timeColumnModel
+	diags := m.TimeColumns.ElementsAs(ctx, &models, false)
+	timeColumns := make([]timeColumn, 0, len(models))
+	for _, model := range models {
+		timeColumns = append(timeColumns, model.apiValue())
+	}
+	sort.Slice(timeColumns, func(i, j int) bool { return timeColumns[i].Name < timeColumns[j].Name })
+	req.TimeColumns = &timeColumns
+	return req, diags
+}
+
+// setFromTable maps an Anomalo API response into the model. The name comes from Anomalo, so warehouse or table
+// renames show up as a diff. Attributes that are null in the model stay null unless adoptUnset is true. See
+// stringFromAPI.
+func (m *tableResourceModel) setFromTable(ctx context.Context, table *tableInformation, adoptUnset bool) diag.Diagnostics {
+	m.TableName = newTableNameValue(fmt.Sprintf("%s.%s", table.Warehouse.Name, table.FullName))
+	m.TableID = types.Int64Value(int64(table.ID))
+	m.NotificationChannelID = types.Int64Value(int64(table.Config.NotificationChannelID))
+	// A null check_cadence_type turns checks off rather than deferring to Anomalo, so it always reflects Anomalo.
+	m.CheckCadenceType = stringFromAPI(m.CheckCadenceType, table.Config.CheckCadenceType, true)
+	m.CheckCadenceRunAtDuration = stringFromAPI(m.CheckCadenceRunAtDuration, table.Config.CheckCadenceRunAtDuration, adoptUnset)
+	m.Definition = stringFromAPI(m.Definition, table.Config.Definition, adoptUnset)
+	m.TimeColumnType = stringFromAPI(m.TimeColumnType, table.Config.TimeColumnType, adoptUnset)
+	m.NotifyAfter = stringFromAPI(m.NotifyAfter, table.Config.NotifyAfter, adoptUnset)
+	m.FreshAfter = stringFromAPI(m.FreshAfter, table.Config.FreshAfter, adoptUnset)
+	m.IntervalSkipExpr = stringFromAPI(m.IntervalSkipExpr, table.Config.IntervalSkipExpr, adoptUnset)
+	m.AlwaysAlertOnErrors = boolFromAPI(m.AlwaysAlertOnErrors, table.Config.AlwaysAlertOnErrors, adoptUnset)
+
+	// Map the set values into the model. Like strings, unset sets stay null.
+	if m.TimeColumns.IsNull() && (!adoptUnset || len(table.Config.TimeColumns) == 0) {
+		m.TimeColumns = types.SetNull(timeColumnObjectType)
+		return nil
+	}
+	timeColumns, diags := timeColumnsSetValue(ctx, table.Config.TimeColumns)
+	m.TimeColumns = timeColumns
+	return diags
 }
 
 // Read refreshes the Terraform state with the latest data.
@@ -253,41 +611,36 @@ func (r *tableResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	table, err := r.client.GetTableInformation(state.TableName.ValueString())
+	table, err := r.fetchTable(ctx, state)
+	if err == nil && table == nil {
+		// The table was dropped from the warehouse, or Anomalo no longer knows about it. Plan to recreate it.
+		tflog.Warn(ctx, "Table no longer exists in Anomalo. Removing it from state.", map[string]interface{}{
+			"table_id":   state.TableID.ValueInt64(),
+			"table_name": state.TableName.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Table",
-			fmt.Sprintf("Could not read table configuration for table name %s, unexpected error: %v",
-				state.TableName.ValueString(), err.Error()),
+			fmt.Sprintf("Could not read table configuration for table ID %d, table name %s, unexpected error: %v",
+				state.TableID.ValueInt64(), state.TableName.ValueString(), err.Error()),
 		)
 		return
 	}
 
-	// Map non-collection attributes into the local state, and set the response state to plan values.
-	state.TableName = types.StringValue(fmt.Sprintf("%s.%s", table.Warehouse.Name, table.FullName))
-	state.TableID = types.Int64Value(int64(table.ID))
-	state.NotificationChannelID = types.Int64Value(int64(table.Config.NotificationChannelID))
-	state.AlwaysAlertOnErrors = types.BoolValue(table.Config.AlwaysAlertOnErrors)
-	state.CheckCadenceRunAtDuration = types.StringValue(table.Config.CheckCadenceRunAtDuration)
-	state.CheckCadenceType = types.StringValue(table.Config.CheckCadenceType)
-	state.Definition = types.StringValue(table.Config.Definition)
-	state.TimeColumnType = types.StringValue(table.Config.TimeColumnType)
-	state.NotifyAfter = types.StringValue(table.Config.NotifyAfter)
-	state.FreshAfter = types.StringValue(table.Config.FreshAfter)
-	state.IntervalSkipExpr = types.StringValue(table.Config.IntervalSkipExpr)
-
-	// Map the list values into the state
-	var listVals []attr.Value
-	for _, val := range table.Config.TimeColumns {
-		// Note: consider using safer string conversion based on runtime type of val
-		listVals = append(listVals, types.StringValue(fmt.Sprintf("%v", val)))
-	}
-	listTimeColumns, diags := types.ListValue(types.StringType, listVals)
+	// The first read after an import adopts every value Anomalo has. See stringFromAPI.
+	importing, diags := req.Private.GetKey(ctx, privateKeyImporting)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(state.setFromTable(ctx, table, importing != nil)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	state.TimeColumns = listTimeColumns
+	if importing != nil {
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, privateKeyImporting, nil)...)
+	}
+	r.tableProtections.record(state.TableID, state.DeletionProtection)
 
 	// Set response state to updated values
 	diags = resp.State.Set(ctx, &state)
@@ -295,6 +648,7 @@ func (r *tableResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	setIdentity(ctx, resp.Identity, state.identity(), &resp.Diagnostics)
 }
 
 func (r *tableResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -319,32 +673,24 @@ func (r *tableResource) Update(ctx context.Context, req resource.UpdateRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	if tableID == 0 {
+		resp.Diagnostics.AddError(
+			"Error Updating Table",
+			fmt.Sprintf("Table %s no longer exists in Anomalo. Run `terraform plan` again to recreate it.",
+				state.TableName.String()),
+		)
+		return
+	}
 
 	// Generate API request body from plan
-	configureTableReq := anomalo.ConfigureTableRequest{
-		TableID:                   tableID,
-		CheckCadenceType:          toPtr(plan.CheckCadenceType.ValueString()),
-		CheckCadenceRunAtDuration: plan.CheckCadenceRunAtDuration.ValueString(),
-		NotificationChannelID:     int(plan.NotificationChannelID.ValueInt64()),
-		Definition:                plan.Definition.ValueString(),
-		AlwaysAlertOnErrors:       plan.AlwaysAlertOnErrors.ValueBool(),
-		TimeColumnType:            plan.TimeColumnType.ValueString(),
-		NotifyAfter:               plan.NotifyAfter.ValueString(),
-		FreshAfter:                plan.FreshAfter.ValueString(),
-		IntervalSkipExpr:          plan.IntervalSkipExpr.ValueString(),
-	}
-
-	// Add list-values from the plan to the request
-	var target []string
-	diags = plan.TimeColumns.ElementsAs(ctx, &target, true)
+	configureTableReq, diags := plan.configureTableRequest(ctx, tableID, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	configureTableReq.TimeColumns = target
 
 	// Update the table
-	configureTableResponse, err := r.client.ConfigureTable(configureTableReq)
+	configureTableResponse, err := configureTable(r.client, configureTableReq)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating Table",
@@ -358,13 +704,13 @@ func (r *tableResource) Update(ctx context.Context, req resource.UpdateRequest,
 	// change based on the API response, so we leave them there.
 	plan.TableID = types.Int64Value(int64(configureTableResponse.ID))
 	plan.NotificationChannelID = types.Int64Value(int64(configureTableReq.NotificationChannelID))
-	plan.AlwaysAlertOnErrors = types.BoolValue(configureTableReq.AlwaysAlertOnErrors)
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	setIdentity(ctx, resp.Identity, plan.identity(), &resp.Diagnostics)
 }
 
 func (r *tableResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -375,11 +721,22 @@ func (r *tableResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	if state.DeletionProtection.ValueBool() {
+		resp.Diagnostics.Append(deletionProtectedError(fmt.Sprintf("table %s", state.TableName.ValueString())))
+		return
+	}
+
 	tableID, diags := r.tableIdForState(state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	if tableID == 0 {
+		tflog.Warn(ctx, "Table no longer exists in Anomalo. Nothing to delete.", map[string]interface{}{
+			"table_name": state.TableName.ValueString(),
+		})
+		return
+	}
 
 	deleteTableReq := anomalo.ConfigureTableRequest{
 		TableID:          tableID,
@@ -387,6 +744,13 @@ func (r *tableResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	}
 
 	_, err := r.client.ConfigureTable(deleteTableReq)
+	if isNotFoundError(err) {
+		tflog.Warn(ctx, "Table no longer exists in Anomalo. Nothing to delete.", map[string]interface{}{
+			"table_id":   tableID,
+			"table_name": state.TableName.ValueString(),
+		})
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting Table",
@@ -397,17 +761,113 @@ func (r *tableResource) Delete(ctx context.Context, req resource.DeleteRequest,
 }
 
 func (r *tableResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Retrieve import ID and save to id attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("table_name"), req, resp)
+	var importID tableImportID
+	if req.ID == "" && req.Identity != nil {
+		// Imported with an `identity` in an import block.
+		var identity tableResourceIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		importID.TableID = int(identity.TableID.ValueInt64())
+	} else {
+		var err error
+		importID, err = parseTableImportID(req.ID)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unexpected Import Identifier",
+				fmt.Sprintf("Could not parse import identifier %q: %s", req.ID, err.Error()),
+			)
+			return
+		}
+	}
+
+	// Resolve every format to the canonical name & ID now, so Read doesn't need to know about import formats.
+	var table *tableInformation
+	var err error
+	switch {
+	case importID.TableID != 0:
+		table, err = getTableInformationByID(r.client, importID.TableID)
+	case importID.WarehouseID != 0:
+		table, err = getTableInformationInWarehouse(r.client, importID.WarehouseID, importID.TableName)
+	default:
+		table, err = getTableInformation(r.client, importID.TableName)
+	}
+	if err == nil && (table == nil || table.ID == 0) {
+		err = fmt.Errorf("table not found")
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Importing Table",
+			fmt.Sprintf("Could not find the table for import identifier %q. Is it accessible by Anomalo? "+
+				"Unexpected error: %s", req.ID, err.Error()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("table_id"), int64(table.ID))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("table_name"),
+		newTableNameValue(fmt.Sprintf("%s.%s", table.Warehouse.Name, table.FullName)))...)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, privateKeyImporting, []byte("true"))...)
+	setIdentity(ctx, resp.Identity, tableResourceIdentityModel{TableID: types.Int64Value(int64(table.ID))},
+		&resp.Diagnostics)
 }
 
+// fetchTable looks up the table by the ID in state, falling back to its name if the ID is missing or the ID lookup
+// fails. Not every Anomalo version supports looking tables up by ID, so ID lookup errors are only logged. It returns
+// nil without an error if the name lookup doesn't find the table, or if the name now refers to a table with a
+// different ID.
+func (r *tableResource) fetchTable(ctx context.Context, state tableResourceModel) (*tableInformation, error) {
+	stateTableID := int(state.TableID.ValueInt64())
+	var idErr error
+	if stateTableID > 0 {
+		table, err := getTableInformationByID(r.client, stateTableID)
+		switch {
+		case err == nil && table.ID == stateTableID:
+			return table, nil
+		case err == nil && table.ID != 0:
+			idErr = fmt.Errorf("looking up table ID %d returned table ID %d", stateTableID, table.ID)
+		case err != nil && !isNotFoundError(err):
+			idErr = err
+		}
+		if idErr != nil {
+			tflog.Warn(ctx, "Unable to look up the table by ID. Looking it up by name instead.", map[string]interface{}{
+				"table_id":   stateTableID,
+				"table_name": state.TableName.ValueString(),
+				"error":      idErr.Error(),
+			})
+		}
+	}
+
+	table, err := getTableInformation(r.client, canonicalTableName(state.TableName.ValueString()))
+	if isNotFoundError(err) || (err == nil && (table == nil || table.ID == 0)) {
+		return nil, nil
+	}
+	if err != nil {
+		if idErr != nil {
+			return nil, fmt.Errorf("%w. Looking the table up by ID also failed: %s", err, idErr.Error())
+		}
+		return nil, err
+	}
+	if stateTableID > 0 && table.ID != stateTableID {
+		// The name now belongs to a different Anomalo table. The table in state (and its identity) is gone.
+		return nil, nil
+	}
+	return table, nil
+}
+
+// tableIdForState returns the Anomalo table ID for the state. It returns 0 without an error if the ID isn't in state
+// and no table with the state's name exists anymore.
 func (r *tableResource) tableIdForState(state tableResourceModel) (int, diag.Diagnostics) {
 	if state.TableID.ValueInt64() > 0 {
 		return int(state.TableID.ValueInt64()), nil
 	} else {
 		// This is unexpected, but table ID is not present in the state. Fetch it based on table name
-		tableName := state.TableName.ValueString()
-		table, err := r.client.GetTableInformation(tableName)
+		tableName := canonicalTableName(state.TableName.ValueString())
+		table, err := getTableInformation(r.client, tableName)
+		if isNotFoundError(err) || (err == nil && (table == nil || table.ID == 0)) {
+			return 0, nil
+		}
 		if err != nil {
 			diagErr := diag.NewErrorDiagnostic(
 				"Error Deleting Table",
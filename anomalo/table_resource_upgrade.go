@@ -0,0 +1,235 @@
+package anomalo
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// UpgradeState migrates state from prior schema versions. Each upgrader converts directly to the current version.
+func (r *tableResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// Version 0 stored unset strings as "", unset always_alert_on_errors as false, and unset time_columns as [].
+		// Anomalo doesn't distinguish those from unset values, so they become null.
+		0: {
+			PriorSchema:   tableResourceSchemaV1(),
+			StateUpgrader: upgradeTableStateV0,
+		},
+		// Version 1 stored time_columns as an ordered list.
+		1: {
+			PriorSchema:   tableResourceSchemaV1(),
+			StateUpgrader: upgradeTableStateV1,
+		},
+		// Version 2 stored time_columns as a set of column names.
+		2: {
+			PriorSchema:   tableResourceSchemaV2(),
+			StateUpgrader: upgradeTableStateV2,
+		},
+	}
+}
+
+// tableResourceModelV1 is tableResourceModel as of schema versions 0 & 1.
+type tableResourceModelV1 struct {
+	TableName                 tableNameValue `tfsdk:"table_name"`
+	TableID                   types.Int64    `tfsdk:"table_id"`
+	CheckCadenceType          types.String   `tfsdk:"check_cadence_type"`
+	CheckCadenceRunAtDuration types.String   `tfsdk:"check_cadence_run_at_duration"`
+	NotificationChannelID     types.Int64    `tfsdk:"notification_channel_id"`
+	Definition                types.String   `tfsdk:"definition"`
+	TimeColumnType            types.String   `tfsdk:"time_column_type"`
+	NotifyAfter               types.String   `tfsdk:"notify_after"`
+	FreshAfter                types.String   `tfsdk:"fresh_after"`
+	IntervalSkipExpr          types.String   `tfsdk:"interval_skip_expr"`
+	AlwaysAlertOnErrors       types.Bool     `tfsdk:"always_alert_on_errors"`
+	TimeColumns               types.List     `tfsdk:"time_columns"`
+	DeletionProtection        types.Bool     `tfsdk:"deletion_protection"`
+}
+
+// tableResourceModelV2 is tableResourceModel as of schema version 2.
+type tableResourceModelV2 struct {
+	TableName                 tableNameValue `tfsdk:"table_name"`
+	TableID                   types.Int64    `tfsdk:"table_id"`
+	CheckCadenceType          types.String   `tfsdk:"check_cadence_type"`
+	CheckCadenceRunAtDuration types.String   `tfsdk:"check_cadence_run_at_duration"`
+	NotificationChannelID     types.Int64    `tfsdk:"notification_channel_id"`
+	Definition                types.String   `tfsdk:"definition"`
+	TimeColumnType            types.String   `tfsdk:"time_column_type"`
+	NotifyAfter               types.String   `tfsdk:"notify_after"`
+	FreshAfter                types.String   `tfsdk:"fresh_after"`
+	IntervalSkipExpr          types.String   `tfsdk:"interval_skip_expr"`
+	AlwaysAlertOnErrors       types.Bool     `tfsdk:"always_alert_on_errors"`
+	TimeColumns               types.Set      `tfsdk:"time_columns"`
+	DeletionProtection        types.Bool     `tfsdk:"deletion_protection"`
+}
+
+// tableResourceSchemaV1 is the schema of versions 0 & 1. Only attribute types matter for decoding prior state, and
+// they're the same in both versions.
+func tableResourceSchemaV1() *schema.Schema {
+	return &schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"table_id":                      schema.Int64Attribute{Computed: true, Optional: true},
+			"table_name":                    schema.StringAttribute{CustomType: tableNameType{}, Required: true},
+			"check_cadence_type":            schema.StringAttribute{Optional: true},
+			"check_cadence_run_at_duration": schema.StringAttribute{Optional: true},
+			"notification_channel_id":       schema.Int64Attribute{Required: true},
+			"definition":                    schema.StringAttribute{Optional: true},
+			"time_column_type":              schema.StringAttribute{Optional: true},
+			"notify_after":                  schema.StringAttribute{Optional: true},
+			"fresh_after":                   schema.StringAttribute{Optional: true},
+			"interval_skip_expr":            schema.StringAttribute{Optional: true},
+			"always_alert_on_errors":        schema.BoolAttribute{Optional: true},
+			"time_columns":                  schema.ListAttribute{Optional: true, ElementType: types.StringType},
+			"deletion_protection":           deletionProtectionAttribute(),
+		},
+	}
+}
+
+// tableResourceSchemaV2 is the schema of version 2.
+func tableResourceSchemaV2() *schema.Schema {
+	priorSchema := tableResourceSchemaV1()
+	priorSchema.Attributes["time_columns"] = schema.SetAttribute{Optional: true, ElementType: types.StringType}
+	return priorSchema
+}
+
+func upgradeTableStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var state tableResourceModelV1
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, value := range []*types.String{
+		&state.CheckCadenceType,
+		&state.CheckCadenceRunAtDuration,
+		&state.Definition,
+		&state.TimeColumnType,
+		&state.NotifyAfter,
+		&state.FreshAfter,
+		&state.IntervalSkipExpr,
+	} {
+		if value.ValueString() == "" {
+			*value = types.StringNull()
+		}
+	}
+	if !state.AlwaysAlertOnErrors.ValueBool() {
+		state.AlwaysAlertOnErrors = types.BoolNull()
+	}
+	if len(state.TimeColumns.Elements()) == 0 {
+		state.TimeColumns = types.ListNull(types.StringType)
+	}
+
+	upgraded, diags := state.upgrade(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	upgraded, diags = upgradeTableModelV2(ctx, upgraded)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, upgraded)...)
+}
+
+func upgradeTableStateV1(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var state tableResourceModelV1
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	upgraded, diags := state.upgrade(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	upgraded, diags = upgradeTableModelV2(ctx, upgraded)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, upgraded)...)
+}
+
+// upgrade converts version 1 state to version 2. time_columns becomes a set.
+func (m tableResourceModelV1) upgrade(ctx context.Context) (tableResourceModel, diag.Diagnostics) {
+	upgraded := tableResourceModel{
+		TableName:                 m.TableName,
+		TableID:                   m.TableID,
+		CheckCadenceType:          m.CheckCadenceType,
+		CheckCadenceRunAtDuration: m.CheckCadenceRunAtDuration,
+		NotificationChannelID:     m.NotificationChannelID,
+		Definition:                m.Definition,
+		TimeColumnType:            m.TimeColumnType,
+		NotifyAfter:               m.NotifyAfter,
+		FreshAfter:                m.FreshAfter,
+		IntervalSkipExpr:          m.IntervalSkipExpr,
+		AlwaysAlertOnErrors:       m.AlwaysAlertOnErrors,
+		TimeColumns:               types.SetNull(types.StringType),
+		DeletionProtection:        m.DeletionProtection,
+	}
+	if m.TimeColumns.IsNull() {
+		return upgraded, nil
+	}
+
+	var timeColumns []string
+	diags := m.TimeColumns.ElementsAs(ctx, &timeColumns, false)
+	if diags.HasError() {
+		return upgraded, diags
+	}
+	upgraded.TimeColumns, diags = stringSetValue(timeColumns)
+	return upgraded, diags
+}
+
+func upgradeTableStateV2(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var state tableResourceModelV2
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	upgraded, diags := upgradeTableModelV2(ctx, tableResourceModel{
+		TableName:                 state.TableName,
+		TableID:                   state.TableID,
+		CheckCadenceType:          state.CheckCadenceType,
+		CheckCadenceRunAtDuration: state.CheckCadenceRunAtDuration,
+		NotificationChannelID:     state.NotificationChannelID,
+		Definition:                state.Definition,
+		TimeColumnType:            state.TimeColumnType,
+		NotifyAfter:               state.NotifyAfter,
+		FreshAfter:                state.FreshAfter,
+		IntervalSkipExpr:          state.IntervalSkipExpr,
+		AlwaysAlertOnErrors:       state.AlwaysAlertOnErrors,
+		TimeColumns:               state.TimeColumns,
+		DeletionProtection:        state.DeletionProtection,
+	})
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, upgraded)...)
+}
+
+// upgradeTableModelV2 converts version 2 state, where time_columns is a set of column names, to the current version.
+// Each name becomes a time column object with no other settings.
+func upgradeTableModelV2(ctx context.Context, m tableResourceModel) (tableResourceModel, diag.Diagnostics) {
+	if m.TimeColumns.IsNull() {
+		m.TimeColumns = types.SetNull(timeColumnObjectType)
+		return m, nil
+	}
+
+	var names []string
+	diags := m.TimeColumns.ElementsAs(ctx, &names, false)
+	if diags.HasError() {
+		return m, diags
+	}
+	columns := make([]timeColumn, 0, len(names))
+	for _, name := range names {
+		columns = append(columns, timeColumn{Name: name})
+	}
+	m.TimeColumns, diags = timeColumnsSetValue(ctx, columns)
+	return m, diags
+}
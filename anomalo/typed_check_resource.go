@@ -0,0 +1,465 @@
+package anomalo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                     = &typedCheckResource{}
+	_ resource.ResourceWithConfigure        = &typedCheckResource{}
+	_ resource.ResourceWithImportState      = &typedCheckResource{}
+	_ resource.ResourceWithModifyPlan       = &typedCheckResource{}
+	_ resource.ResourceWithIdentity         = &typedCheckResource{}
+	_ resource.ResourceWithConfigValidators = &typedCheckResource{}
+)
+
+// typedCheckSpec describes a check type with its own resource, ex. `anomalo_check_row_count`. Each param of the check
+// type is a top level attribute of the resource, named after the param, so it can be typed, validated, and
+// documented. Params are strings, numbers, bools, or lists of strings.
+type typedCheckSpec struct {
+	// typeName is appended to `<provider>_check_` to name the resource.
+	typeName string
+	// checkType is the Anomalo check type, as in `anomalo_check.check_type`.
+	checkType        string
+	description      string
+	params           map[string]schema.Attribute
+	configValidators []resource.ConfigValidator
+}
+
+func newTypedCheckResource(spec typedCheckSpec) func() resource.Resource {
+	return func() resource.Resource {
+		return &typedCheckResource{spec: spec}
+	}
+}
+
+// typedCheckResource manages a check of a single type. It converts its attributes to & from the model of
+// `anomalo_check`, passing params as `params_json`, and shares that resource's create, update & delete lifecycle.
+type typedCheckResource struct {
+	core checkResource
+	spec typedCheckSpec
+}
+
+func (r *typedCheckResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.core.Configure(ctx, req, resp)
+}
+
+// Metadata returns the resource type name.
+func (r *typedCheckResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_check_" + r.spec.typeName
+}
+
+// Schema defines the schema for the resource.
+func (r *typedCheckResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	attributes := map[string]schema.Attribute{
+		"check_static_id": schema.Int64Attribute{
+			Computed: true,
+			PlanModifiers: []planmodifier.Int64{
+				// Update keeps the static ID. Replacements are planned without prior state, so they get a new one.
+				int64planmodifier.UseStateForUnknown(),
+			},
+			Description: "The check ID, persists through updates. The Anomalo API implements check updates as a " +
+				"deletion of the old check + creation of a new one, which this resource hides.",
+		},
+		"table_id": schema.Int64Attribute{
+			Required: true,
+			PlanModifiers: []planmodifier.Int64{
+				int64planmodifier.RequiresReplace(),
+			},
+			Description: "The ID of the table that this check belongs to, ex `anomalo_table.<resource_name>.table_id`. " +
+				"Changing it creates a new check.",
+		},
+		"ref": schema.StringAttribute{
+			Computed: true,
+			Optional: true,
+			Description: "A table-scoped, unique, human-readable identifier for the check that persists across " +
+				"updates. Anomalo generates one if it isn't set.",
+		},
+		"effective_params": schema.MapAttribute{
+			Computed:    true,
+			ElementType: types.StringType,
+			Description: "Every param of the check in Anomalo, including defaults Anomalo fills in for attributes " +
+				"that aren't configured.",
+		},
+		"deletion_protection": deletionProtectionAttribute(),
+		"adopt_existing":      adoptExistingCheckAttribute(),
+	}
+	for name, attribute := range checkAttributeSchemas() {
+		attributes[name] = attribute
+	}
+	for name, attribute := range r.spec.params {
+		attributes[name] = attribute
+	}
+
+	resp.Schema = schema.Schema{
+		// No upgraders yet. See CONTRIBUTING.md.
+		Version: 0,
+		Description: fmt.Sprintf("An Anomalo `%s` check. %s Equivalent to an `anomalo_check` with "+
+			"`check_type = \"%s\"`, with each param as a typed attribute. Only configured attributes are compared "+
+			"with Anomalo when detecting drift.", r.spec.checkType, r.spec.description, r.spec.checkType),
+		Attributes: attributes,
+	}
+}
+
+// IdentitySchema defines the identity schema for the resource.
+func (r *typedCheckResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	r.core.IdentitySchema(ctx, req, resp)
+}
+
+// ConfigValidators returns validators that apply to the resource as a whole.
+func (r *typedCheckResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return r.spec.configValidators
+}
+
+// ModifyPlan applies resource-level plan logic that can't be expressed with attribute plan modifiers.
+func (r *typedCheckResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var state types.Object
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+	stateModel := r.checkModel(state.Attributes(), false)
+	planDeletionProtection(ctx, req, resp, r.core.defaultDeletionProtection, stateModel.description())
+	if resp.Diagnostics.HasError() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan types.Object
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.validateColumns(ctx, plan.Attributes(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() || req.State.Raw.IsNull() {
+		return
+	}
+
+	// Update only recreates the check if its configuration in Anomalo changes. Otherwise Anomalo's params don't change.
+	planModel := r.checkModel(plan.Attributes(), false)
+	if planModel.EffectiveParams.IsUnknown() && planModel.sameCheck(ctx, stateModel) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("effective_params"), stateModel.EffectiveParams)...)
+	}
+	if configuredChangeRequiresReplace(ctx, req, &resp.Diagnostics, path.Root("table_id")) {
+		warnIfProtectedReplacement(ctx, req.State, stateModel.description(), &resp.Diagnostics)
+		return
+	}
+	r.core.planRecreation(ctx, planModel, stateModel, &resp.Diagnostics)
+}
+
+// validateColumns checks that attributes naming columns refer to columns of the check's table. See
+// anomalo_check.validateColumnParams.
+func (r *typedCheckResource) validateColumns(ctx context.Context, attrs map[string]attr.Value, diags *diag.Diagnostics) {
+	tableID, _ := attrs["table_id"].(types.Int64)
+	if tableID.IsNull() || tableID.IsUnknown() {
+		return
+	}
+	for _, key := range r.paramNames() {
+		if !columnParams[key] {
+			continue
+		}
+		var names []string
+		switch value := attrs[key].(type) {
+		case types.String:
+			names = parseColumnParam(value.ValueString())
+		case types.List:
+			for _, name := range value.Elements() {
+				if name, ok := name.(types.String); ok && !name.IsUnknown() {
+					names = append(names, name.ValueString())
+				}
+			}
+		}
+		r.core.columns.validateColumns(ctx, int(tableID.ValueInt64()),
+			fmt.Sprintf("table ID %d", tableID.ValueInt64()), path.Root(key), names, diags)
+	}
+}
+
+// paramNames returns the names of the check type's params, sorted.
+func (r *typedCheckResource) paramNames() []string {
+	names := make([]string, 0, len(r.spec.params))
+	for name := range r.spec.params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// checkModel converts the resource's attributes to the model of `anomalo_check`. Null attributes are left out of the
+// params. When adopt is true (ex. imports), params are left null so that every param Anomalo has is read.
+func (r *typedCheckResource) checkModel(attrs map[string]attr.Value, adopt bool) checkResourceModel {
+	model := checkResourceModel{
+		CheckType:    types.StringValue(r.spec.checkType),
+		Params:       types.MapNull(types.StringType),
+		ParamsJSON:   newParamsJSONNull(),
+		IgnoreParams: types.SetNull(types.StringType),
+	}
+	model.TableID, _ = attrs["table_id"].(types.Int64)
+	model.CheckStaticID, _ = attrs["check_static_id"].(types.Int64)
+	model.Ref, _ = attrs["ref"].(types.String)
+	model.EffectiveParams, _ = attrs["effective_params"].(types.Map)
+	model.DeletionProtection, _ = attrs["deletion_protection"].(types.Bool)
+	model.AdoptExisting, _ = attrs["adopt_existing"].(types.Bool)
+	model.PriorityLevel, _ = attrs["priority_level"].(types.String)
+	model.Description, _ = attrs["description"].(types.String)
+	model.Enabled, _ = attrs["enabled"].(types.Bool)
+	model.NotificationChannelID, _ = attrs["notification_channel_id"].(types.Int64)
+	if adopt {
+		return model
+	}
+
+	params := map[string]interface{}{}
+	for _, key := range r.paramNames() {
+		val, ok := attrs[key]
+		if !ok || val.IsNull() {
+			continue
+		}
+		if val.IsUnknown() {
+			model.ParamsJSON = paramsJSONValue{StringValue: types.StringUnknown()}
+			return model
+		}
+		params[key] = typedParamRequestValue(val)
+	}
+	// Encoding strings, numbers, bools & lists of strings can't fail.
+	model.ParamsJSON, _ = newParamsJSONValue(params)
+	return model
+}
+
+// setFromCheckModel sets the resource's attributes from the model of `anomalo_check`. Params are only set when
+// withParams is true. Otherwise they keep their planned value.
+func (r *typedCheckResource) setFromCheckModel(ctx context.Context, attrs map[string]attr.Value, model checkResourceModel,
+	withParams bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+	attrs["table_id"] = model.TableID
+	attrs["check_static_id"] = model.CheckStaticID
+	attrs["ref"] = model.Ref
+	attrs["effective_params"] = model.EffectiveParams
+	attrs["deletion_protection"] = model.DeletionProtection
+	attrs["priority_level"] = model.PriorityLevel
+	attrs["description"] = model.Description
+	attrs["enabled"] = model.Enabled
+	attrs["notification_channel_id"] = model.NotificationChannelID
+	if !withParams {
+		return diags
+	}
+
+	params := model.priorParams()
+	for _, key := range r.paramNames() {
+		typ := r.spec.params[key].GetType()
+		val, ok := params[key]
+		if !ok {
+			null, err := typ.ValueFromTerraform(ctx, tftypes.NewValue(typ.TerraformType(ctx), nil))
+			if err != nil {
+				diags.AddError("Error Reading Check Params", err.Error())
+				continue
+			}
+			attrs[key] = null
+			continue
+		}
+		value, err := typedParamValue(typ, val)
+		if err != nil {
+			diags.AddAttributeError(path.Root(key), "Unexpected Check Param",
+				fmt.Sprintf("Anomalo returned %q for `%s`, which can't be read as a %s: %s",
+					paramString(val), key, typ.String(), err.Error()))
+			continue
+		}
+		attrs[key] = value
+	}
+	return diags
+}
+
+// typedParamRequestValue converts a known attribute value to a param for a create_check request.
+func typedParamRequestValue(val attr.Value) interface{} {
+	switch v := val.(type) {
+	case types.String:
+		return v.ValueString()
+	case types.Int64:
+		return v.ValueInt64()
+	case types.Float64:
+		return v.ValueFloat64()
+	case types.Bool:
+		return v.ValueBool()
+	case types.List:
+		elements := []interface{}{}
+		for _, element := range v.Elements() {
+			if element, ok := element.(types.String); ok {
+				elements = append(elements, element.ValueString())
+			}
+		}
+		return elements
+	default:
+		return val.String()
+	}
+}
+
+// typedParamValue converts a param from Anomalo to an attribute value of the provided type. Anomalo may return
+// numbers & bools as strings, and lists as comma-separated strings, so those are parsed.
+func typedParamValue(typ attr.Type, val interface{}) (attr.Value, error) {
+	switch {
+	case typ.Equal(types.StringType):
+		return types.StringValue(paramString(val)), nil
+	case typ.Equal(types.Int64Type):
+		n, err := strconv.ParseInt(paramString(val), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return types.Int64Value(n), nil
+	case typ.Equal(types.Float64Type):
+		f, err := strconv.ParseFloat(paramString(val), 64)
+		if err != nil {
+			return nil, err
+		}
+		return types.Float64Value(f), nil
+	case typ.Equal(types.BoolType):
+		b, err := strconv.ParseBool(paramString(val))
+		if err != nil {
+			return nil, err
+		}
+		return types.BoolValue(b), nil
+	case typ.Equal(types.ListType{ElemType: types.StringType}):
+		var names []string
+		if list, ok := val.([]interface{}); ok {
+			for _, element := range list {
+				names = append(names, paramString(element))
+			}
+		} else {
+			names = parseColumnParam(paramString(val))
+		}
+		elements := make([]attr.Value, 0, len(names))
+		for _, name := range names {
+			elements = append(elements, types.StringValue(name))
+		}
+		list, diags := types.ListValue(types.StringType, elements)
+		if diags.HasError() {
+			return nil, fmt.Errorf("invalid list")
+		}
+		return list, nil
+	default:
+		return nil, fmt.Errorf("unsupported attribute type %s", typ.String())
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *typedCheckResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan types.Object
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	attrs := plan.Attributes()
+	model := r.checkModel(attrs, false)
+	resp.Diagnostics.Append(r.core.create(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(r.setFromCheckModel(ctx, attrs, model, false)...)
+	r.setState(ctx, plan, attrs, model, &resp.State, resp.Identity, &resp.Diagnostics)
+}
+
+func (r *typedCheckResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state types.Object
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The first read after an import adopts every param Anomalo has.
+	importing, diags := req.Private.GetKey(ctx, privateKeyImporting)
+	resp.Diagnostics.Append(diags...)
+	attrs := state.Attributes()
+	model := r.checkModel(attrs, importing != nil)
+	found, diags := r.core.read(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if model.CheckType.ValueString() != r.spec.checkType {
+		resp.Diagnostics.AddError(
+			"Unexpected Check Type",
+			fmt.Sprintf("The %s is a %s check, but this resource only manages %s checks. Manage it with an "+
+				"`anomalo_check` resource instead.", model.description(), model.CheckType.ValueString(),
+				r.spec.checkType),
+		)
+		return
+	}
+	if importing != nil {
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, privateKeyImporting, nil)...)
+	}
+
+	resp.Diagnostics.Append(r.setFromCheckModel(ctx, attrs, model, true)...)
+	r.setState(ctx, state, attrs, model, &resp.State, resp.Identity, &resp.Diagnostics)
+}
+
+func (r *typedCheckResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state types.Object
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	attrs := plan.Attributes()
+	model := r.checkModel(attrs, false)
+	resp.Diagnostics.Append(r.core.update(ctx, &model, r.checkModel(state.Attributes(), false))...)
+	if resp.Diagnostics.HasError() {
+		// Only verified updates are saved. See anomalo_check.
+		resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+		return
+	}
+	resp.Diagnostics.Append(r.setFromCheckModel(ctx, attrs, model, false)...)
+	r.setState(ctx, plan, attrs, model, &resp.State, resp.Identity, &resp.Diagnostics)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *typedCheckResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state types.Object
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.core.delete(ctx, r.checkModel(state.Attributes(), false))...)
+}
+
+// ImportState accepts the same identifiers as `anomalo_check`. The check type is verified by the following Read.
+func (r *typedCheckResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	r.core.ImportState(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, privateKeyImporting, []byte("true"))...)
+}
+
+// setState stores the updated attributes of the resource, typed like prior, along with its identity.
+func (r *typedCheckResource) setState(ctx context.Context, prior types.Object, attrs map[string]attr.Value,
+	model checkResourceModel, state *tfsdk.State, identity *tfsdk.ResourceIdentity, diags *diag.Diagnostics) {
+	if diags.HasError() {
+		return
+	}
+	updated, objDiags := types.ObjectValue(prior.AttributeTypes(ctx), attrs)
+	diags.Append(objDiags...)
+	if diags.HasError() {
+		return
+	}
+	diags.Append(state.Set(ctx, updated)...)
+	if diags.HasError() {
+		return
+	}
+	setIdentity(ctx, identity, model.identity(), diags)
+}
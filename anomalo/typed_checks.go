@@ -0,0 +1,152 @@
+package anomalo
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// typedChecks are the check types with their own resource. Other check types are managed with `anomalo_check`.
+var typedChecks = []typedCheckSpec{
+	{
+		typeName:    "time_column_near_now",
+		checkType:   "TimeColumnNearNow",
+		description: "Checks that the latest value of a time column is recent, ex. that new data is arriving on time.",
+		params: map[string]schema.Attribute{
+			"time_column_target": schema.StringAttribute{
+				Required:    true,
+				Validators:  []validator.String{stringvalidator.LengthAtLeast(1)},
+				Description: "The time column to check. Validated against the table's columns during plan.",
+			},
+			"window_unit_now": schema.StringAttribute{
+				Optional:    true,
+				Validators:  []validator.String{stringvalidator.OneOf("minutes", "hours", "days")},
+				Description: "The unit of `window_begin_delta` & `window_end_delta`. One of `minutes`, `hours`, or `days`.",
+			},
+			"window_begin_delta": schema.Int64Attribute{
+				Optional:   true,
+				Validators: []validator.Int64{int64validator.AtMost(0)},
+				Description: "The start of the window that the latest time must be in, relative to now. Ex. `-26` " +
+					"with `window_unit_now = \"hours\"` is 26 hours ago.",
+			},
+			"window_end_delta": schema.Int64Attribute{
+				Optional:    true,
+				Validators:  []validator.Int64{int64validator.AtMost(0)},
+				Description: "The end of the window that the latest time must be in, relative to now.",
+			},
+			"time_when_lag_intervals": schema.Int64Attribute{
+				Optional: true,
+				Description: "The number of intervals that the check's window lags behind now. See the Anomalo API " +
+					"documentation for `TimeColumnNearNow`.",
+			},
+			"time_based": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether the check is time-based. See the Anomalo API documentation for `TimeColumnNearNow`.",
+			},
+			"pass_on_no_data_error": schema.BoolAttribute{
+				Optional:    true,
+				Description: "When true, the check passes rather than fails if the table has no data.",
+			},
+		},
+	},
+	{
+		typeName:    "row_count",
+		checkType:   "RowCount",
+		description: "Checks that the number of rows in the table is within bounds.",
+		params: map[string]schema.Attribute{
+			"min_row_count": schema.Int64Attribute{
+				Optional:    true,
+				Validators:  []validator.Int64{int64validator.AtLeast(0)},
+				Description: "The minimum number of rows. At least one of `min_row_count` or `max_row_count` is required.",
+			},
+			"max_row_count": schema.Int64Attribute{
+				Optional:    true,
+				Validators:  []validator.Int64{int64validator.AtLeast(0)},
+				Description: "The maximum number of rows. At least one of `min_row_count` or `max_row_count` is required.",
+			},
+			"where_clause": whereClauseAttribute(),
+		},
+		configValidators: []resource.ConfigValidator{
+			resourcevalidator.AtLeastOneOf(path.MatchRoot("min_row_count"), path.MatchRoot("max_row_count")),
+		},
+	},
+	{
+		typeName:    "null_values",
+		checkType:   "NullValues",
+		description: "Checks that a column has few or no null values.",
+		params: map[string]schema.Attribute{
+			"column_name": schema.StringAttribute{
+				Required:    true,
+				Validators:  []validator.String{stringvalidator.LengthAtLeast(1)},
+				Description: "The column to check. Validated against the table's columns during plan.",
+			},
+			"max_null_ratio": schema.Float64Attribute{
+				Optional:    true,
+				Validators:  []validator.Float64{float64validator.Between(0, 1)},
+				Description: "The fraction of rows, from 0 to 1, that may be null. Anomalo defaults to 0.",
+			},
+			"segment_columns": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators:  []validator.List{listvalidator.SizeAtLeast(1)},
+				Description: "Columns to segment the table by. The null ratio is checked for each segment.",
+			},
+			"where_clause": whereClauseAttribute(),
+		},
+	},
+	{
+		typeName:    "custom_sql",
+		checkType:   "CustomSQL",
+		description: "Runs a SQL query against the table's warehouse. The check fails if the query returns rows.",
+		params: map[string]schema.Attribute{
+			"custom_sql": schema.StringAttribute{
+				Required:   true,
+				Validators: []validator.String{stringvalidator.LengthAtLeast(1)},
+				Description: "The query, which should return the rows that fail the check. It's compared ignoring " +
+					"whitespace, line endings, and trailing semicolons outside of quoted text and comments, so " +
+					"reformatting it doesn't recreate the check.",
+			},
+		},
+	},
+	{
+		typeName:    "unique",
+		checkType:   "Unique",
+		description: "Checks that no two rows have the same values in a set of columns.",
+		params: map[string]schema.Attribute{
+			"column_names": schema.ListAttribute{
+				Required:    true,
+				ElementType: types.StringType,
+				Validators:  []validator.List{listvalidator.SizeAtLeast(1)},
+				Description: "The columns whose combined values must be unique. Validated against the table's columns " +
+					"during plan.",
+			},
+			"where_clause": whereClauseAttribute(),
+		},
+	},
+}
+
+// whereClauseAttribute is the `where_clause` attribute shared by check types that can filter the rows they check.
+func whereClauseAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional:   true,
+		Validators: []validator.String{stringvalidator.LengthAtLeast(1)},
+		Description: "A SQL filter, without `WHERE`, for the rows to check. It's compared ignoring whitespace, line " +
+			"endings, and trailing semicolons outside of quoted text and comments.",
+	}
+}
+
+// typedCheckResources returns a constructor for the resource of each typed check.
+func typedCheckResources() []func() resource.Resource {
+	constructors := make([]func() resource.Resource, 0, len(typedChecks))
+	for _, spec := range typedChecks {
+		constructors = append(constructors, newTypedCheckResource(spec))
+	}
+	return constructors
+}
@@ -10,6 +10,10 @@ import anomalo
 logger = logging.getLogger(__name__)
 
 color_code_regex = re.compile(r'\x1B\[\d+(;\d+){0,2}m')
+attribute_regex = re.compile(r'^(\s*)(\w+)\s*=\s*(.*)$')
+
+# Attributes that only the provider sets. `terraform state show` prints them, but they're invalid in configuration.
+computed_only_attributes = ["effective_params"]
 
 
 def get_anomalo_client(secret_file, host, api_token):
@@ -34,6 +38,27 @@ def get_anomalo_client(secret_file, host, api_token):
     print("Successfully connected to Anomalo")
     return client
 
+def strip_computed_attributes(resource_state):
+    """Removes computed-only attributes from `terraform state show` output, so it can be used as configuration."""
+    output = []
+    closing_line = None
+    for line in color_code_regex.sub('', resource_state).splitlines():
+        if closing_line is not None:
+            # Multi-line values end with a bracket at the indentation of their attribute.
+            if line == closing_line:
+                closing_line = None
+            continue
+
+        match = attribute_regex.match(line)
+        if match and match.group(2) in computed_only_attributes:
+            indent, value = match.group(1), match.group(3).strip()
+            if value in ("{", "["):
+                closing_line = indent + ("}" if value == "{" else "]")
+            continue
+        output.append(line)
+    return "\n".join(output)
+
+
 def tables_from_file(file_path):
     with open(file_path, 'r') as f:
         tables = [line.strip() for line in f]
@@ -108,7 +133,7 @@ def run(args):
 
                 imported_check_state = (run_terraform(terraform_client, "state", "show", state_reference))
                 print(imported_check_state)
-                final_file_output.append(imported_check_state)
+                final_file_output.append(strip_computed_attributes(imported_check_state))
         except Exception as e:
             logger.error(e)
             failed_tables.append((table_name, f"Unable to execute terraform commands for table {table_name}. Skipping"))
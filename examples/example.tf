@@ -32,9 +32,9 @@ resource "anomalo_table" "VariationsTable" {
 resource "anomalo_check" "VariationsGeneratedRecently" {
     check_type      = "TimeColumnNearNow"
     table_id        = anomalo_table.VariationsTable.table_id
+    priority_level  = "normal"
     params          = {
         "pass_on_no_data_error"   = "false"
-        "priority_level"          = "normal"
         "time_based"              = "false"
         "time_column_target"      = "_ingested_at"
         "time_when_lag_intervals" = "0"
@@ -0,0 +1,19 @@
+list "anomalo_check" "variations_checks" {
+  provider         = anomalo
+  include_resource = true
+
+  config {
+    table_id   = 1234
+    check_type = "TimeColumnNearNow"
+    ref_prefix = "freshness_"
+  }
+}
+
+# Without a table_id, checks are listed on every configured table.
+list "anomalo_check" "all_freshness_checks" {
+  provider = anomalo
+
+  config {
+    check_type = "TimeColumnNearNow"
+  }
+}
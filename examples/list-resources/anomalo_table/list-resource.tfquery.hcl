@@ -0,0 +1,10 @@
+list "anomalo_table" "configured_analytics_tables" {
+  provider         = anomalo
+  include_resource = true
+
+  config {
+    warehouse_name  = "square"
+    schema_pattern  = "analytics_*"
+    configured_only = true
+  }
+}
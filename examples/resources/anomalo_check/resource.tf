@@ -1,9 +1,9 @@
 resource "anomalo_check" "VariationsGeneratedRecently" {
     check_type      = "TimeColumnNearNow"
     table_id        = anomalo_table.VariationsTable.id
+    priority_level  = "normal"
     params          = {
         "pass_on_no_data_error"   = "false"
-        "priority_level"          = "normal"
         "time_based"              = "false"
         "time_column_target"      = "_ingested_at"
         "time_when_lag_intervals" = "0"
@@ -12,3 +12,13 @@ resource "anomalo_check" "VariationsGeneratedRecently" {
         "window_unit_now"         = "hours"
     }
 }
+
+resource "anomalo_check" "VariationsSegmentedNullCheck" {
+    check_type      = "NullValues"
+    table_id        = anomalo_table.VariationsTable.id
+    params_json     = jsonencode({
+        "column_name"    = "merchant_token"
+        "max_null_ratio" = 0.001
+        "segments"       = ["country", "channel"]
+    })
+}
@@ -0,0 +1,8 @@
+resource "anomalo_check_custom_sql" "VariationsHavePositivePrices" {
+    table_id   = anomalo_table.VariationsTable.id
+    custom_sql = <<-SQL
+        SELECT variation_token
+        FROM warehouse.schema.variations
+        WHERE price < 0
+    SQL
+}
@@ -0,0 +1,6 @@
+resource "anomalo_check_null_values" "VariationsSegmentedNullCheck" {
+    table_id        = anomalo_table.VariationsTable.id
+    column_name     = "merchant_token"
+    max_null_ratio  = 0.001
+    segment_columns = ["country", "channel"]
+}
@@ -0,0 +1,6 @@
+resource "anomalo_check_row_count" "VariationsNotEmpty" {
+    table_id       = anomalo_table.VariationsTable.id
+    min_row_count  = 1
+    where_clause   = "country = 'US'"
+    priority_level = "high"
+}
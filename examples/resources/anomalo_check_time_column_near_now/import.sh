@@ -0,0 +1,14 @@
+# By table ID & check static ID
+terraform import anomalo_check_time_column_near_now.check_name table_id,check_static_id
+
+# By table ID & check ref
+terraform import anomalo_check_time_column_near_now.check_name table_id,,check_ref # Note the double comma
+
+# By fully qualified table name & check ref
+terraform import anomalo_check_time_column_near_now.check_name warehouse_name.schema_name.table_name/check_ref
+
+# By fully qualified table name & check static ID
+terraform import anomalo_check_time_column_near_now.check_name warehouse_name.schema_name.table_name#check_static_id
+
+# By Anomalo UI check URL
+terraform import anomalo_check_time_column_near_now.check_name https://anomalo.example.com/dashboard/tables/1234/checks/56
@@ -0,0 +1,11 @@
+resource "anomalo_check_time_column_near_now" "VariationsGeneratedRecently" {
+    table_id                = anomalo_table.VariationsTable.id
+    priority_level          = "normal"
+    time_column_target      = "_ingested_at"
+    window_unit_now         = "hours"
+    window_begin_delta      = -26
+    window_end_delta        = 0
+    time_when_lag_intervals = 0
+    time_based              = false
+    pass_on_no_data_error   = false
+}
@@ -0,0 +1,4 @@
+resource "anomalo_check_unique" "VariationTokensAreUnique" {
+    table_id     = anomalo_table.VariationsTable.id
+    column_names = ["merchant_token", "variation_token"]
+}
@@ -1 +1,11 @@
+# By fully qualified table name
 terraform import anomalo_table.table_name warehouse_name.schema_name.table_name
+
+# By Anomalo table ID
+terraform import anomalo_table.table_name 1234
+
+# By warehouse ID & table name
+terraform import anomalo_table.table_name 12:schema_name.table_name
+
+# By Anomalo UI table URL
+terraform import anomalo_table.table_name https://anomalo.example.com/dashboard/tables/1234
@@ -5,4 +5,8 @@ resource "anomalo_table" "VariationsTable" {
     check_cadence_type            = "daily"
     check_cadence_run_at_duration = "PT6H"
     always_alert_on_errors        = true
+    time_columns = [
+        { name = "updated_at" },
+        { name = "ds", format = "%Y-%m-%d", timezone = "UTC" },
+    ]
 }